@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ホールカード2枚の種類。AA、AKs、AKoなど169種類ある
+type HandClass struct {
+	// 高いほうのランク。Aは14
+	High int
+	// 低いほうのランク。ペアならHighと同じ
+	Low    int
+	Suited bool
+}
+
+// 強いペアから順に、169種類すべてのハンドクラス
+func AllHandClasses() []HandClass {
+	classes := []HandClass{}
+	for high := 14; high >= 2; high-- {
+		classes = append(classes, HandClass{High: high, Low: high})
+	}
+	for high := 14; high >= 2; high-- {
+		for low := high - 1; low >= 2; low-- {
+			classes = append(classes, HandClass{High: high, Low: low, Suited: true})
+			classes = append(classes, HandClass{High: high, Low: low})
+		}
+	}
+	return classes
+}
+
+func (h HandClass) IsPair() bool {
+	return h.High == h.Low
+}
+
+// "AA"、"AKs"、"T9o"のような表記
+func (h HandClass) String() string {
+	notation := rankNotation(h.High) + rankNotation(h.Low)
+	switch {
+	case h.IsPair():
+		return notation
+	case h.Suited:
+		return notation + "s"
+	default:
+		return notation + "o"
+	}
+}
+
+// このクラスに含まれる組み合わせの数。ペアは6、スーテッドは4、オフスートは12
+func (h HandClass) NumberOfCombos() int {
+	switch {
+	case h.IsPair():
+		return 6
+	case h.Suited:
+		return 4
+	default:
+		return 12
+	}
+}
+
+// このクラスに含まれる具体的な組み合わせ
+func (h HandClass) combos() []valueobject.Combo {
+	combos := []valueobject.Combo{}
+	for _, combo := range h.handRange().Combos() {
+		combos = append(combos, combo.Combo)
+	}
+	return combos
+}
+
+// このクラスだけを含むレンジ
+func (h HandClass) handRange() *valueobject.HandRange {
+	handRange, err := valueobject.ParseHandRange(h.String())
+	if err != nil {
+		return valueobject.NewHandRange(nil)
+	}
+	return handRange
+}
+
+// heroCombosのそれぞれと重ならないvillainCombosの数の平均
+func unblockedCombos(heroCombos, villainCombos []valueobject.Combo) float64 {
+	total := 0
+	for _, h := range heroCombos {
+		for _, v := range villainCombos {
+			if !h.Overlaps(v) {
+				total++
+			}
+		}
+	}
+	return float64(total) / float64(len(heroCombos))
+}
+
+func rankNotation(rank int) string {
+	return valueobject.RankNotation(rankValue(rank))
+}
+
+func rankValue(rank int) string {
+	for value, r := range valueobject.ValueRankMap() {
+		if r == rank {
+			return value
+		}
+	}
+	return ""
+}
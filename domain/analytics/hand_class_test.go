@@ -0,0 +1,98 @@
+package analytics
+
+import "testing"
+
+func TestAllHandClasses(t *testing.T) {
+	classes := AllHandClasses()
+	if len(classes) != 169 {
+		t.Fatalf("len(AllHandClasses()) = %d, want 169", len(classes))
+	}
+	seen := map[HandClass]bool{}
+	combos := 0
+	for _, class := range classes {
+		if seen[class] {
+			t.Errorf("AllHandClasses() contains %s twice", class)
+		}
+		seen[class] = true
+		combos += class.NumberOfCombos()
+		if len(class.combos()) != class.NumberOfCombos() {
+			t.Errorf("%s has %d combos, want %d", class, len(class.combos()), class.NumberOfCombos())
+		}
+	}
+	if combos != 1326 {
+		t.Errorf("total combos = %d, want 1326", combos)
+	}
+}
+
+func TestHandClass_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		class HandClass
+		want  string
+	}{
+		{
+			name:  "ペア",
+			class: HandClass{High: 14, Low: 14},
+			want:  "AA",
+		},
+		{
+			name:  "スーテッド",
+			class: HandClass{High: 14, Low: 13, Suited: true},
+			want:  "AKs",
+		},
+		{
+			name:  "オフスート",
+			class: HandClass{High: 10, Low: 9},
+			want:  "T9o",
+		},
+		{
+			name:  "小さいペア",
+			class: HandClass{High: 2, Low: 2},
+			want:  "22",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.class.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnblockedCombos(t *testing.T) {
+	aces := HandClass{High: 14, Low: 14}
+	tests := []struct {
+		name    string
+		villain HandClass
+		want    float64
+	}{
+		{
+			name:    "同じペア",
+			villain: aces,
+			want:    1,
+		},
+		{
+			name:    "カードを共有しないペア",
+			villain: HandClass{High: 13, Low: 13},
+			want:    6,
+		},
+		{
+			name:    "Aを含むスーテッド",
+			villain: HandClass{High: 14, Low: 13, Suited: true},
+			want:    2,
+		},
+		{
+			name:    "Aを含むオフスート",
+			villain: HandClass{High: 14, Low: 13},
+			want:    6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unblockedCombos(aces.combos(), tt.villain.combos()); !almostEqual(got, tt.want) {
+				t.Errorf("unblockedCombos() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ICMで扱える最大の人数。状態数が2のmaxICMPlayers乗になるため制限する
+const maxICMPlayers = 20
+
+// Independent Chip Model (Malmuth-Harville) による各プレイヤーの賞金の期待値
+// 各順位には、残ったプレイヤーのうちスタックの割合に比例した確率で入ると考える
+// payoutsは1位から順の賞金。チップを持っていないプレイヤーはすでに脱落したものとして賞金を受け取らない
+func ICMEquity(stacks []int, payouts []float64) ([]float64, error) {
+	total, err := validateStacks(stacks)
+	if err != nil {
+		return nil, err
+	}
+	n := len(stacks)
+	places := min(len(payouts), n)
+	// probabilities[mask]は、maskのプレイヤーが上位len(mask)位を占める確率
+	probabilities := make([]float64, 1<<n)
+	sums := make([]int, 1<<n)
+	probabilities[0] = 1
+	equities := make([]float64, n)
+	for mask := 0; mask < len(probabilities); mask++ {
+		if mask > 0 {
+			lowest := bits.TrailingZeros(uint(mask))
+			sums[mask] = sums[mask&(mask-1)] + stacks[lowest]
+		}
+		place := bits.OnesCount(uint(mask))
+		remaining := total - sums[mask]
+		if probabilities[mask] == 0 || place >= places || remaining == 0 {
+			continue
+		}
+		for i, stack := range stacks {
+			if mask&(1<<i) != 0 || stack == 0 {
+				continue
+			}
+			p := probabilities[mask] * float64(stack) / float64(remaining)
+			equities[i] += p * payouts[place]
+			probabilities[mask|1<<i] += p
+		}
+	}
+	return equities, nil
+}
+
+// チップの割合に比例した賞金の期待値 (チップEV)
+func ChipEquity(stacks []int, payouts []float64) ([]float64, error) {
+	total, err := validateStacks(stacks)
+	if err != nil {
+		return nil, err
+	}
+	prizePool := 0.0
+	for _, payout := range payouts {
+		prizePool += payout
+	}
+	equities := make([]float64, len(stacks))
+	for i, stack := range stacks {
+		equities[i] = prizePool * float64(stack) / float64(total)
+	}
+	return equities, nil
+}
+
+// スタックの変化によるICMでの期待値の変化
+// ICMEquityでafterとbeforeを比べたhero番目のプレイヤーの差分で、オールインの勝ち負けなどの判断に使う
+func ICMChange(before, after []int, payouts []float64, hero int) (float64, error) {
+	if len(before) != len(after) {
+		return 0, errors.New("stacks must have the same number of players")
+	}
+	if hero < 0 || hero >= len(before) {
+		return 0, errors.New("hero is out of range")
+	}
+	beforeEquities, err := ICMEquity(before, payouts)
+	if err != nil {
+		return 0, err
+	}
+	afterEquities, err := ICMEquity(after, payouts)
+	if err != nil {
+		return 0, err
+	}
+	return afterEquities[hero] - beforeEquities[hero], nil
+}
+
+func validateStacks(stacks []int) (int, error) {
+	if len(stacks) == 0 {
+		return 0, errors.New("stacks must not be empty")
+	}
+	if len(stacks) > maxICMPlayers {
+		return 0, errors.New("too many players")
+	}
+	total := 0
+	for _, stack := range stacks {
+		if stack < 0 {
+			return 0, errors.New("stack must not be negative")
+		}
+		total += stack
+	}
+	if total == 0 {
+		return 0, errors.New("total stack must be positive")
+	}
+	return total, nil
+}
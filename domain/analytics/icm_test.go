@@ -0,0 +1,188 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+)
+
+// 全順位の並びを列挙してICMを求める
+func bruteForceICM(stacks []int, payouts []float64) []float64 {
+	equities := make([]float64, len(stacks))
+	var visit func(remaining []int, place int, probability float64)
+	visit = func(remaining []int, place int, probability float64) {
+		if place >= len(payouts) || len(remaining) == 0 {
+			return
+		}
+		total := 0
+		for _, i := range remaining {
+			total += stacks[i]
+		}
+		for k, i := range remaining {
+			p := probability * float64(stacks[i]) / float64(total)
+			equities[i] += p * payouts[place]
+			rest := append(append([]int{}, remaining[:k]...), remaining[k+1:]...)
+			visit(rest, place+1, p)
+		}
+	}
+	players := []int{}
+	for i := range stacks {
+		players = append(players, i)
+	}
+	visit(players, 0, 1)
+	return equities
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestICMEquity(t *testing.T) {
+	tests := []struct {
+		name    string
+		stacks  []int
+		payouts []float64
+	}{
+		{
+			name:    "同じスタックのヘッズアップ",
+			stacks:  []int{1000, 1000},
+			payouts: []float64{70, 30},
+		},
+		{
+			name:    "3人で3位まで入賞",
+			stacks:  []int{5000, 3000, 2000},
+			payouts: []float64{50, 30, 20},
+		},
+		{
+			name:    "5人で2位まで入賞",
+			stacks:  []int{4000, 2500, 1500, 1200, 800},
+			payouts: []float64{65, 35},
+		},
+		{
+			name:    "入賞者数が人数より多い",
+			stacks:  []int{300, 100},
+			payouts: []float64{50, 30, 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ICMEquity(tt.stacks, tt.payouts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := bruteForceICM(tt.stacks, tt.payouts)
+			for i := range want {
+				if !almostEqual(got[i], want[i]) {
+					t.Errorf("ICMEquity()[%d] = %v, want %v", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestICMEquity_WinnerTakesAll(t *testing.T) {
+	stacks := []int{600, 300, 100}
+	payouts := []float64{1000}
+	icm, err := ICMEquity(stacks, payouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chip, err := ChipEquity(stacks, payouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range stacks {
+		if !almostEqual(icm[i], chip[i]) {
+			t.Errorf("ICMEquity()[%d] = %v, want chip equity %v", i, icm[i], chip[i])
+		}
+	}
+}
+
+func TestICMEquity_TenPlayers(t *testing.T) {
+	stacks := []int{12000, 9500, 8000, 7000, 6000, 4500, 3000, 2500, 1500, 1000}
+	payouts := []float64{3000, 2000, 1400, 1000, 800, 650, 500, 400, 250}
+	got, err := ICMEquity(stacks, payouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0.0
+	for i, equity := range got {
+		total += equity
+		if i > 0 && equity > got[i-1] {
+			t.Errorf("ICMEquity()[%d] = %v is greater than a bigger stack's %v", i, equity, got[i-1])
+		}
+	}
+	if !almostEqual(total, 10000) {
+		t.Errorf("sum of ICMEquity() = %v, want 10000", total)
+	}
+	// 最も短いスタックでも9位までの賞金があるため、チップの割合より多くの期待値を持つ
+	chip, err := ChipEquity(stacks, payouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[9] <= chip[9] || got[0] >= chip[0] {
+		t.Errorf("ICMEquity() = %v, want short stacks above and big stacks below chip equity %v", got, chip)
+	}
+}
+
+func TestICMEquity_BustedPlayer(t *testing.T) {
+	got, err := ICMEquity([]int{500, 0, 500}, []float64{60, 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{50, 0, 50}
+	for i := range want {
+		if !almostEqual(got[i], want[i]) {
+			t.Errorf("ICMEquity()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestICMEquity_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		stacks []int
+	}{
+		{
+			name:   "プレイヤーがいない",
+			stacks: []int{},
+		},
+		{
+			name:   "負のスタック",
+			stacks: []int{100, -1},
+		},
+		{
+			name:   "全員のスタックが0",
+			stacks: []int{0, 0},
+		},
+		{
+			name:   "人数が多すぎる",
+			stacks: make([]int, maxICMPlayers+1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ICMEquity(tt.stacks, []float64{100}); err == nil {
+				t.Errorf("ICMEquity() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestICMChange(t *testing.T) {
+	before := []int{3000, 3000, 4000}
+	after := []int{6000, 0, 4000}
+	payouts := []float64{50, 30, 20}
+	icm, err := ICMChange(before, after, payouts, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chipBefore, _ := ChipEquity(before, payouts)
+	chipAfter, _ := ChipEquity(after, payouts)
+	// 入賞圏ではチップを倍にしても期待値は倍にならない
+	if icm <= 0 || icm >= chipAfter[0]-chipBefore[0] {
+		t.Errorf("ICMChange() = %v, want between 0 and the chip equity change %v", icm, chipAfter[0]-chipBefore[0])
+	}
+	if _, err := ICMChange(before, after[:2], payouts, 0); err == nil {
+		t.Errorf("ICMChange() with different lengths error = nil, want error")
+	}
+}
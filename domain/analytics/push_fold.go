@@ -0,0 +1,372 @@
+package analytics
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/KoheiMatsuno99/poker/domain/domainservice"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// heroとvillainがオールインしてボードを最後まで配ったときのheroの勝率。引き分けは半分の勝ちとして数える
+type EquityFunc func(hero, villain HandClass) (float64, error)
+
+// プッシュかフォールドだけで判断する場面
+type PushFoldSpot struct {
+	// ハンドに参加する人数。最後の2人がスモールブラインドとビッグブラインドで、ヘッズアップではスモールブラインドが先にアクションする
+	Players int
+	// 有効スタック。ビッグブラインドを1とした単位で、アンティを支払う前の額
+	Stack float64
+	// ビッグブラインドを1としたスモールブラインドの額
+	SmallBlind float64
+	// ビッグブラインドを1とした1人あたりのアンティ
+	Ante float64
+}
+
+// ナッシュ均衡に近いプッシュとコールのレンジ
+// ポジションはアクションする順の番号で、0が最初にアクションするプレイヤー
+type PushFoldChart struct {
+	spot    PushFoldSpot
+	classes []HandClass
+	index   map[HandClass]int
+	// push[i][c]は、前の全員がフォールドしたときに、ポジションiのプレイヤーがクラスcでプッシュするかどうか
+	push [][]bool
+	// call[i][j][c]は、ポジションiのプッシュに対して、ポジションjのプレイヤーがクラスcでコールするかどうか
+	call       [][][]bool
+	iterations int
+	converged  bool
+}
+
+// 全員が互いの最善応答になるまでプッシュとコールのレンジを更新し、プッシュ/フォールドのチャートを作る
+// 3人以上の場合は、コールするプレイヤーは1人だけと考えて近似する
+func SolvePushFold(spot PushFoldSpot, equity EquityFunc, maxIterations int) (*PushFoldChart, error) {
+	if spot.Players < 2 || spot.Players > 10 {
+		return nil, errors.New("number of players must be between 2 and 10")
+	}
+	if spot.SmallBlind < 0 || spot.SmallBlind > 1 || spot.Ante < 0 {
+		return nil, errors.New("invalid blinds")
+	}
+	if spot.Stack-spot.Ante < 1 {
+		return nil, errors.New("stack must cover the ante and the big blind")
+	}
+	if maxIterations <= 0 {
+		return nil, errors.New("iterations must be positive")
+	}
+	chart := &PushFoldChart{spot: spot, classes: AllHandClasses(), index: map[HandClass]int{}}
+	for i, class := range chart.classes {
+		chart.index[class] = i
+	}
+	solver, err := newPushFoldSolver(chart, equity)
+	if err != nil {
+		return nil, err
+	}
+	// 最善応答をそのまま使うと境目のハンドでレンジが振動するため、これまでの最善応答の平均に対して応答する (仮想プレイ)
+	push := make([][]float64, spot.Players-1)
+	for i := range push {
+		push[i] = make([]float64, len(chart.classes))
+		for c := range push[i] {
+			push[i][c] = 1
+		}
+	}
+	call := solver.bestCalls(push)
+	chart.push, chart.call = toRanges(push), toCallRanges(call)
+	for chart.iterations < maxIterations {
+		chart.iterations++
+		rate := 1 / float64(chart.iterations+1)
+		averageCalls(call, solver.bestCalls(push), rate)
+		average(push, solver.bestPushes(call), rate)
+		pushRanges, callRanges := toRanges(push), toCallRanges(call)
+		if chart.iterations > 1 && equalRanges(pushRanges, chart.push) && equalCallRanges(callRanges, chart.call) {
+			chart.converged = true
+			break
+		}
+		chart.push, chart.call = pushRanges, callRanges
+	}
+	return chart, nil
+}
+
+func (c *PushFoldChart) Spot() PushFoldSpot {
+	return c.spot
+}
+
+// レンジが変わらなくなるまで更新できたかどうか
+func (c *PushFoldChart) Converged() bool {
+	return c.converged
+}
+
+func (c *PushFoldChart) ShouldPush(position int, class HandClass) bool {
+	if position < 0 || position >= len(c.push) {
+		return false
+	}
+	return c.push[position][c.index[class]]
+}
+
+func (c *PushFoldChart) ShouldCall(pusher, caller int, class HandClass) bool {
+	if pusher < 0 || pusher >= len(c.call) || caller <= pusher || caller >= c.spot.Players {
+		return false
+	}
+	return c.call[pusher][caller][c.index[class]]
+}
+
+// プッシュするハンドの組み合わせの割合
+func (c *PushFoldChart) PushPercentage(position int) float64 {
+	if position < 0 || position >= len(c.push) {
+		return 0
+	}
+	return c.percentage(c.push[position])
+}
+
+func (c *PushFoldChart) CallPercentage(pusher, caller int) float64 {
+	if pusher < 0 || pusher >= len(c.call) || caller <= pusher || caller >= c.spot.Players {
+		return 0
+	}
+	return c.percentage(c.call[pusher][caller])
+}
+
+func (c *PushFoldChart) PushRange(position int) (*valueobject.HandRange, error) {
+	if position < 0 || position >= len(c.push) {
+		return nil, errors.New("position does not push first in")
+	}
+	return c.handRange(c.push[position])
+}
+
+func (c *PushFoldChart) CallRange(pusher, caller int) (*valueobject.HandRange, error) {
+	if pusher < 0 || pusher >= len(c.call) || caller <= pusher || caller >= c.spot.Players {
+		return nil, errors.New("invalid positions")
+	}
+	return c.handRange(c.call[pusher][caller])
+}
+
+func (c *PushFoldChart) percentage(selected []bool) float64 {
+	combos := 0
+	for i, ok := range selected {
+		if ok {
+			combos += c.classes[i].NumberOfCombos()
+		}
+	}
+	return float64(combos) / 1326
+}
+
+func (c *PushFoldChart) handRange(selected []bool) (*valueobject.HandRange, error) {
+	notations := []string{}
+	for i, ok := range selected {
+		if ok {
+			notations = append(notations, c.classes[i].String())
+		}
+	}
+	return valueobject.ParseHandRange(strings.Join(notations, ","))
+}
+
+// レンジの最善応答を計算するための、クラス同士の勝率と組み合わせの数
+type pushFoldSolver struct {
+	spot   PushFoldSpot
+	equity [][]float64
+	// weights[h][v]は、heroがクラスhを持っているときに残っているクラスvの組み合わせの数
+	weights [][]float64
+	// totals[h]は、heroがクラスhを持っているときに残っているすべての組み合わせの数
+	totals []float64
+}
+
+func newPushFoldSolver(chart *PushFoldChart, equity EquityFunc) (*pushFoldSolver, error) {
+	n := len(chart.classes)
+	solver := &pushFoldSolver{
+		spot:    chart.spot,
+		equity:  make([][]float64, n),
+		weights: make([][]float64, n),
+		totals:  make([]float64, n),
+	}
+	combos := make([][]valueobject.Combo, n)
+	for h, class := range chart.classes {
+		solver.equity[h] = make([]float64, n)
+		solver.weights[h] = make([]float64, n)
+		combos[h] = class.combos()
+	}
+	for h, hero := range chart.classes {
+		for v, villain := range chart.classes {
+			if v < h {
+				solver.equity[h][v] = 1 - solver.equity[v][h]
+			} else {
+				e, err := equity(hero, villain)
+				if err != nil {
+					return nil, err
+				}
+				solver.equity[h][v] = e
+			}
+			solver.weights[h][v] = unblockedCombos(combos[h], combos[v])
+			solver.totals[h] += solver.weights[h][v]
+		}
+	}
+	return solver, nil
+}
+
+// ポジションiのプレイヤーが支払うブラインド
+func (s *pushFoldSolver) post(position int) float64 {
+	switch position {
+	case s.spot.Players - 1:
+		return 1
+	case s.spot.Players - 2:
+		return s.spot.SmallBlind
+	default:
+		return 0
+	}
+}
+
+// アンティを支払った後の、オールインで賭ける額
+func (s *pushFoldSolver) allIn() float64 {
+	return s.spot.Stack - s.spot.Ante
+}
+
+// ポジションiのプッシュにポジションjがコールしたときのポット
+func (s *pushFoldSolver) calledPot(i, j int) float64 {
+	return 2*s.allIn() + float64(s.spot.Players)*s.spot.Ante + s.spot.SmallBlind + 1 - s.post(i) - s.post(j)
+}
+
+// 全員がフォールドしたときにプッシュしたプレイヤーが得る額
+func (s *pushFoldSolver) deadMoney() float64 {
+	return float64(s.spot.Players)*s.spot.Ante + s.spot.SmallBlind + 1
+}
+
+// 各プッシュレンジに対して、コールしたほうが期待値の高いハンド
+// push[i][c]はポジションiがクラスcでプッシュする頻度で、戻り値も同じ形の頻度
+func (s *pushFoldSolver) bestCalls(push [][]float64) [][][]float64 {
+	calls := make([][][]float64, len(push))
+	for i := range push {
+		calls[i] = make([][]float64, s.spot.Players)
+		for j := i + 1; j < s.spot.Players; j++ {
+			calls[i][j] = make([]float64, len(s.equity))
+			for c := range s.equity {
+				weight, equity := 0.0, 0.0
+				for v, frequency := range push[i] {
+					weight += frequency * s.weights[c][v]
+					equity += frequency * s.weights[c][v] * s.equity[c][v]
+				}
+				if weight > 0 && equity/weight*s.calledPot(i, j) > s.allIn()-s.post(j) {
+					calls[i][j][c] = 1
+				}
+			}
+		}
+	}
+	return calls
+}
+
+// 後ろのプレイヤーのコールレンジに対して、プッシュしたほうがフォールドより期待値の高いハンド
+func (s *pushFoldSolver) bestPushes(calls [][][]float64) [][]float64 {
+	pushes := make([][]float64, len(calls))
+	for i := range calls {
+		pushes[i] = make([]float64, len(s.equity))
+		for h := range s.equity {
+			gain, reach := 0.0, 1.0
+			for j := i + 1; j < s.spot.Players; j++ {
+				called, calledGain := 0.0, 0.0
+				for v, frequency := range calls[i][j] {
+					called += frequency * s.weights[h][v]
+					calledGain += frequency * s.weights[h][v] * (s.equity[h][v]*s.calledPot(i, j) - (s.allIn() - s.post(i)))
+				}
+				gain += reach * calledGain / s.totals[h]
+				reach *= 1 - called/s.totals[h]
+			}
+			if gain+reach*s.deadMoney() > 0 {
+				pushes[i][h] = 1
+			}
+		}
+	}
+	return pushes
+}
+
+// 頻度を最善応答の方向にrateだけ近づける。rateを1/(反復回数+1)にすると全反復の平均になる
+func average(frequencies, responses [][]float64, rate float64) {
+	for i := range frequencies {
+		for c := range frequencies[i] {
+			frequencies[i][c] += (responses[i][c] - frequencies[i][c]) * rate
+		}
+	}
+}
+
+func averageCalls(frequencies, responses [][][]float64, rate float64) {
+	for i := range frequencies {
+		average(frequencies[i], responses[i], rate)
+	}
+}
+
+// 頻度が半分以上のハンドをレンジに含める
+func toRanges(frequencies [][]float64) [][]bool {
+	ranges := make([][]bool, len(frequencies))
+	for i := range frequencies {
+		ranges[i] = make([]bool, len(frequencies[i]))
+		for c, frequency := range frequencies[i] {
+			ranges[i][c] = frequency >= 0.5
+		}
+	}
+	return ranges
+}
+
+func toCallRanges(frequencies [][][]float64) [][][]bool {
+	ranges := make([][][]bool, len(frequencies))
+	for i := range frequencies {
+		ranges[i] = toRanges(frequencies[i])
+	}
+	return ranges
+}
+
+func equalRanges(a, b [][]bool) bool {
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for c := range a[i] {
+			if a[i][c] != b[i][c] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func equalCallRanges(a, b [][][]bool) bool {
+	for i := range a {
+		if !equalRanges(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SampledEquityで求めた勝率。サンプル数ごとに、すべてのSampledEquityで共有する
+// プリフロップの勝率表を作るには時間がかかるため、スタックや人数を変えてチャートを作り直すときに再利用する
+var sampledEquities = struct {
+	sync.Mutex
+	tables map[int]map[[2]HandClass]float64
+}{tables: map[int]map[[2]HandClass]float64{}}
+
+// ランダムなボードをsamples回配って勝率を求めるEquityFunc。同じ組み合わせの結果は再利用する
+func SampledEquity(samples int) EquityFunc {
+	return func(hero, villain HandClass) (float64, error) {
+		sampledEquities.Lock()
+		defer sampledEquities.Unlock()
+		cache, ok := sampledEquities.tables[samples]
+		if !ok {
+			cache = map[[2]HandClass]float64{}
+			sampledEquities.tables[samples] = cache
+		}
+		key := [2]HandClass{hero, villain}
+		if equity, ok := cache[key]; ok {
+			return equity, nil
+		}
+		equity, err := sampleEquity(hero, villain, samples)
+		if err != nil {
+			return 0, err
+		}
+		cache[key] = equity
+		cache[[2]HandClass{villain, hero}] = 1 - equity
+		return equity, nil
+	}
+}
+
+func sampleEquity(hero, villain HandClass, samples int) (float64, error) {
+	result, err := domainservice.RangeVsRangeEquity(hero.handRange(), villain.handRange(), nil, samples)
+	if err != nil {
+		return 0, err
+	}
+	return result.Equity(), nil
+}
@@ -0,0 +1,189 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+)
+
+// ペアとハイカードの強さだけで決める簡易的な勝率。SampledEquityより高速で結果が決まっている
+func strengthEquity(hero, villain HandClass) (float64, error) {
+	strength := func(class HandClass) float64 {
+		score := float64(class.High*2+class.Low) / 42
+		if class.IsPair() {
+			score += 0.5
+		}
+		if class.Suited {
+			score += 0.05
+		}
+		return score
+	}
+	equity := 0.5 + 0.4*(strength(hero)-strength(villain))
+	return math.Max(0.05, math.Min(0.95, equity)), nil
+}
+
+func solveForTest(t *testing.T, spot PushFoldSpot) *PushFoldChart {
+	t.Helper()
+	chart, err := SolvePushFold(spot, strengthEquity, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chart
+}
+
+func TestSolvePushFold_HeadsUp(t *testing.T) {
+	aces := HandClass{High: 14, Low: 14}
+	trash := HandClass{High: 7, Low: 2}
+	tests := []struct {
+		name      string
+		stack     float64
+		pushTrash bool
+	}{
+		{
+			name:      "2BBなら72oでもプッシュする",
+			stack:     2,
+			pushTrash: true,
+		},
+		{
+			name:      "20BBなら72oはフォールドする",
+			stack:     20,
+			pushTrash: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := solveForTest(t, PushFoldSpot{Players: 2, Stack: tt.stack, SmallBlind: 0.5})
+			if !chart.Converged() {
+				t.Errorf("Converged() = false, want true")
+			}
+			if !chart.ShouldPush(0, aces) || !chart.ShouldCall(0, 1, aces) {
+				t.Errorf("AA should be pushed and called")
+			}
+			if got := chart.ShouldPush(0, trash); got != tt.pushTrash {
+				t.Errorf("ShouldPush(72o) = %v, want %v", got, tt.pushTrash)
+			}
+		})
+	}
+}
+
+func TestSolvePushFold_ShorterStackPushesWider(t *testing.T) {
+	previous := 1.0
+	for _, stack := range []float64{2, 5, 10, 20} {
+		chart := solveForTest(t, PushFoldSpot{Players: 2, Stack: stack, SmallBlind: 0.5})
+		got := chart.PushPercentage(0)
+		if got > previous {
+			t.Errorf("PushPercentage() at %vBB = %v, want at most %v", stack, got, previous)
+		}
+		previous = got
+	}
+}
+
+func TestSolvePushFold_ThreePlayers(t *testing.T) {
+	chart := solveForTest(t, PushFoldSpot{Players: 3, Stack: 10, SmallBlind: 0.5, Ante: 0.1})
+	aces := HandClass{High: 14, Low: 14}
+	for position := 0; position < 2; position++ {
+		if !chart.ShouldPush(position, aces) {
+			t.Errorf("ShouldPush(%d, AA) = false, want true", position)
+		}
+	}
+	if !chart.ShouldCall(0, 1, aces) || !chart.ShouldCall(0, 2, aces) {
+		t.Errorf("AA should call a push from the button")
+	}
+	// ビッグブラインドは最初にアクションしない
+	if chart.ShouldPush(2, aces) || chart.PushPercentage(2) != 0 {
+		t.Errorf("big blind must not have a push range")
+	}
+	if chart.CallPercentage(1, 0) != 0 {
+		t.Errorf("CallPercentage() of an earlier position = %v, want 0", chart.CallPercentage(1, 0))
+	}
+}
+
+func TestPushFoldChart_PushRange(t *testing.T) {
+	chart := solveForTest(t, PushFoldSpot{Players: 2, Stack: 10, SmallBlind: 0.5})
+	pushRange, err := chart.PushRange(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pushRange.Size()/1326, chart.PushPercentage(0); !almostEqual(got, want) {
+		t.Errorf("PushRange() covers %v of hands, want %v", got, want)
+	}
+	callRange, err := chart.CallRange(0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := callRange.Size()/1326, chart.CallPercentage(0, 1); !almostEqual(got, want) {
+		t.Errorf("CallRange() covers %v of hands, want %v", got, want)
+	}
+	if _, err := chart.PushRange(1); err == nil {
+		t.Errorf("PushRange() of the big blind error = nil, want error")
+	}
+}
+
+func TestSolvePushFold_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		spot       PushFoldSpot
+		equity     EquityFunc
+		iterations int
+	}{
+		{
+			name:       "人数が少ない",
+			spot:       PushFoldSpot{Players: 1, Stack: 10, SmallBlind: 0.5},
+			iterations: 10,
+		},
+		{
+			name:       "スモールブラインドがビッグブラインドより大きい",
+			spot:       PushFoldSpot{Players: 2, Stack: 10, SmallBlind: 2},
+			iterations: 10,
+		},
+		{
+			name:       "スタックがビッグブラインドに足りない",
+			spot:       PushFoldSpot{Players: 2, Stack: 1, SmallBlind: 0.5, Ante: 0.5},
+			iterations: 10,
+		},
+		{
+			name:       "反復回数が0",
+			spot:       PushFoldSpot{Players: 2, Stack: 10, SmallBlind: 0.5},
+			iterations: 0,
+		},
+		{
+			name:       "勝率を計算できない",
+			spot:       PushFoldSpot{Players: 2, Stack: 10, SmallBlind: 0.5},
+			equity:     SampledEquity(0),
+			iterations: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equity := tt.equity
+			if equity == nil {
+				equity = strengthEquity
+			}
+			if _, err := SolvePushFold(tt.spot, equity, tt.iterations); err == nil {
+				t.Errorf("SolvePushFold() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestSampledEquity(t *testing.T) {
+	equity := SampledEquity(300)
+	aces := HandClass{High: 14, Low: 14}
+	trash := HandClass{High: 7, Low: 2}
+	got, err := equity(aces, trash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got < 0.75 {
+		t.Errorf("equity(AA, 72o) = %v, want at least 0.75", got)
+	}
+	if reverse, _ := equity(trash, aces); !almostEqual(got+reverse, 1) {
+		t.Errorf("equity(AA, 72o) + equity(72o, AA) = %v, want 1", got+reverse)
+	}
+	// 同じサンプル数のSampledEquityは、計算済みの勝率を共有する
+	if cached, _ := SampledEquity(300)(aces, trash); cached != got {
+		t.Errorf("cached equity(AA, 72o) = %v, want %v", cached, got)
+	}
+	if _, err := SampledEquity(0)(aces, trash); err == nil {
+		t.Errorf("SampledEquity(0) error = nil, want error")
+	}
+}
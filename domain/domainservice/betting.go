@@ -0,0 +1,317 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+type ActionType int
+
+const (
+	ActionFold ActionType = iota
+	ActionCheck
+	ActionCall
+	ActionBet
+	ActionRaise
+	ActionAllIn
+	ActionDraw
+	ActionShow
+	ActionMuck
+)
+
+func (a ActionType) String() string {
+	switch a {
+	case ActionFold:
+		return "fold"
+	case ActionCheck:
+		return "check"
+	case ActionCall:
+		return "call"
+	case ActionBet:
+		return "bet"
+	case ActionRaise:
+		return "raise"
+	case ActionAllIn:
+		return "all-in"
+	case ActionDraw:
+		return "draw"
+	case ActionShow:
+		return "show"
+	case ActionMuck:
+		return "muck"
+	default:
+		return "unknown"
+	}
+}
+
+// ベッティングラウンドでのアクション
+// ベットとレイズのAmountは、このラウンドで合計いくらにするか (ベット額やレイズ後の額) を表す
+type Action struct {
+	Type   ActionType
+	Amount int
+}
+
+// 現在アクションするプレイヤー。ベッティングラウンド中でなければnil
+func (t *Table) Actor() *entity.Player {
+	if t.phase != PhaseBetting || t.actor < 0 {
+		return nil
+	}
+	return t.players[t.actor]
+}
+
+func (t *Table) HasFolded(player *entity.Player) bool {
+	return t.folded[player]
+}
+
+// フォールドしていないプレイヤー
+func (t *Table) RemainingPlayers() []*entity.Player {
+	remaining := []*entity.Player{}
+	for _, player := range t.players {
+		if !t.folded[player] {
+			remaining = append(remaining, player)
+		}
+	}
+	return remaining
+}
+
+// フォールドもオールインもしておらず、まだアクションできるプレイヤーの数
+func (t *Table) countCanAct() int {
+	count := 0
+	for _, player := range t.players {
+		if t.canAct(player) {
+			count++
+		}
+	}
+	return count
+}
+
+func (t *Table) canAct(player *entity.Player) bool {
+	return !t.folded[player] && player.Stack() > 0
+}
+
+// プレイヤーがこのラウンドでまだアクションする必要があるかどうか
+func (t *Table) needsAction(player *entity.Player) bool {
+	if !t.canAct(player) {
+		return false
+	}
+	if player.Chips() < t.CurrentBet() {
+		return true
+	}
+	return !t.acted[player] && t.countCanAct() >= 2
+}
+
+// ベットできる最小額
+func (t *Table) minimumBet() int {
+	switch {
+	case t.blinds.BigBlind > 0:
+		return t.blinds.BigBlind
+	case t.blinds.Ante > 0:
+		return t.blinds.Ante
+	default:
+		return 1
+	}
+}
+
+// 次のレイズで最低限上乗せしなければならない額
+func (t *Table) MinimumRaise() int {
+	if t.lastRaiseSize > 0 {
+		return t.lastRaiseSize
+	}
+	return t.minimumBet()
+}
+
+func (t *Table) startBettingRound(round int) {
+	t.phase = PhaseBetting
+	t.bettingRound = round
+	t.acted = map[*entity.Player]bool{}
+	t.lastRaiseSize = 0
+	t.raiseCount = 0
+	t.lastAggressor = nil
+	t.fullRaiseBet = t.CurrentBet()
+	// ビッグブラインドは最初のベットとして数える
+	if t.CurrentBet() > 0 {
+		t.raiseCount = 1
+	}
+	t.actor = -1
+	start := 0
+	// ドロー前はビッグブラインド (ストラドルがあればストラドル) の次のプレイヤーから、ドロー後はボタンの次のプレイヤーからアクションする
+	if round == 1 {
+		lastBlind := t.livePlayerAt(t.bigBlindSeat)
+		if t.straddler != nil {
+			lastBlind = t.straddler
+			// ストラドルはレイズとして数え、次のレイズはストラドルの額以上上乗せする
+			t.lastRaiseSize = t.straddler.Chips()
+			t.raiseCount++
+		}
+		if lastBlind != nil {
+			for i, player := range t.players {
+				if player == lastBlind {
+					start = (i + 1) % len(t.players)
+				}
+			}
+		}
+	}
+	t.actor = t.nextActor(start)
+	if t.actor < 0 {
+		t.endBettingRound()
+	}
+}
+
+// fromから順に、アクションが必要なプレイヤーのインデックスを返す
+func (t *Table) nextActor(from int) int {
+	for i := 0; i < len(t.players); i++ {
+		index := (from + i) % len(t.players)
+		if t.needsAction(t.players[index]) {
+			return index
+		}
+	}
+	return -1
+}
+
+func (t *Table) permittedBettingActions(player *entity.Player) []ActionType {
+	actions := []ActionType{ActionFold}
+	toCall := t.CurrentBet() - player.Chips()
+	if toCall <= 0 {
+		actions = append(actions, ActionCheck)
+	} else {
+		actions = append(actions, ActionCall)
+	}
+	if t.canRaise(player) {
+		if t.CurrentBet() == 0 {
+			actions = append(actions, ActionBet)
+		} else {
+			actions = append(actions, ActionRaise)
+		}
+	}
+	// オールインはコールに足りない場合か、ストラクチャーの上限内でレイズできる場合に限る
+	_, maximum := t.bettingStructure.RaiseRange(t, player)
+	if player.Stack() <= toCall || (t.canRaise(player) && player.Chips()+player.Stack() <= maximum) {
+		actions = append(actions, ActionAllIn)
+	}
+	return actions
+}
+
+// 現在のアクション番のプレイヤーがアクションする
+func (t *Table) Act(player *entity.Player, action Action) error {
+	if err := t.requirePhase("act", PhaseBetting); err != nil {
+		return err
+	}
+	if t.Actor() != player {
+		return fmt.Errorf("it is not %s's turn", player.Name())
+	}
+	if !containsAction(t.permittedBettingActions(player), action.Type) {
+		return fmt.Errorf("%s is not permitted", action.Type)
+	}
+	currentBet, chipsBefore := t.CurrentBet(), player.Chips()
+	switch action.Type {
+	case ActionFold:
+		t.folded[player] = true
+	case ActionCheck:
+	case ActionCall:
+		if err := player.Bet(min(currentBet-player.Chips(), player.Stack())); err != nil {
+			return err
+		}
+	case ActionBet, ActionRaise:
+		if err := t.validateRaise(player, action.Amount); err != nil {
+			return err
+		}
+		if err := player.Bet(action.Amount - player.Chips()); err != nil {
+			return err
+		}
+	case ActionAllIn:
+		if err := player.Bet(player.Stack()); err != nil {
+			return err
+		}
+	}
+	t.record(LedgerBet, player, player.Chips()-chipsBefore)
+	if player.Chips() > currentBet {
+		t.lastAggressor = player
+		t.onRaise(player, currentBet)
+	}
+	t.acted[player] = true
+	t.advanceBetting()
+	return nil
+}
+
+// ベット額やレイズ後の額が有効かどうかを確かめる
+func (t *Table) validateRaise(player *entity.Player, amount int) error {
+	if amount-player.Chips() > player.Stack() {
+		return fmt.Errorf("not enough chips")
+	}
+	minimum, maximum := t.RaiseRange(player)
+	if amount < minimum {
+		return fmt.Errorf("raise must be at least %d", minimum)
+	}
+	if amount > maximum {
+		return fmt.Errorf("raise must be at most %d", maximum)
+	}
+	return nil
+}
+
+// ベットやレイズが行われたとき、他のプレイヤーが再びアクションできるようにする
+// 最低レイズ額に満たないオールインは、それまでの額の足りないオールインと合わせてフルレイズにならない限り、
+// すでにアクションしたプレイヤーにレイズの権利を与えない
+func (t *Table) onRaise(player *entity.Player, previousBet int) {
+	if raiseSize := player.Chips() - previousBet; raiseSize >= t.MinimumRaise() {
+		t.lastRaiseSize = raiseSize
+	} else if player.Chips()-t.fullRaiseBet < t.MinimumRaise() {
+		return
+	}
+	t.fullRaiseBet = player.Chips()
+	t.raiseCount++
+	t.acted = map[*entity.Player]bool{}
+}
+
+func (t *Table) advanceBetting() {
+	if len(t.RemainingPlayers()) == 1 {
+		// 1人以外がフォールドした場合は、ショーダウンせずに支払いへ進む
+		t.returnUncalledBet()
+		t.CollectBets()
+		t.phase = PhasePayout
+		return
+	}
+	t.actor = t.nextActor(t.actor + 1)
+	if t.actor < 0 {
+		t.endBettingRound()
+	}
+}
+
+func (t *Table) endBettingRound() {
+	t.returnUncalledBet()
+	t.CollectBets()
+	t.actor = -1
+	if t.bettingRound == 1 {
+		t.startDraw()
+		return
+	}
+	t.startShowdown()
+}
+
+// 最も大きい掛け金のうち、他の誰の掛け金も届かない額をそのプレイヤーに返す
+func (t *Table) returnUncalledBet() {
+	var top *entity.Player
+	highest, second := 0, 0
+	for _, player := range t.players {
+		switch chips := player.Chips(); {
+		case chips > highest:
+			top, highest, second = player, chips, highest
+		case chips > second:
+			second = chips
+		}
+	}
+	if top == nil || highest == second {
+		return
+	}
+	// 返す額は掛け金以下なので失敗しない
+	_ = top.ReturnBet(t.record(LedgerRefund, top, highest-second))
+}
+
+func containsAction(actions []ActionType, action ActionType) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// ベットとレイズの額を制限するベッティングストラクチャー
+type BettingStructure interface {
+	// playerがベットやレイズでこのラウンドの合計をいくらにできるか。スタックによる制限は含まない
+	RaiseRange(t *Table, player *entity.Player) (minimum, maximum int)
+	// このラウンドでまだベットやレイズができるかどうか
+	CanRaise(t *Table) bool
+}
+
+// ノーリミット。直前のレイズ幅以上であれば、スタックの全額までレイズできる
+type NoLimit struct{}
+
+func (NoLimit) RaiseRange(t *Table, player *entity.Player) (int, int) {
+	return t.CurrentBet() + t.MinimumRaise(), player.Chips() + player.Stack()
+}
+
+func (NoLimit) CanRaise(t *Table) bool {
+	return true
+}
+
+// ポットリミット。コールした後のポットの額までレイズできる
+// ブラインドが足りずにオールインしてポットが小さい場合でも、最低レイズ額まではレイズできる
+type PotLimit struct{}
+
+func (PotLimit) RaiseRange(t *Table, player *entity.Player) (int, int) {
+	toCall := t.CurrentBet() - player.Chips()
+	minimum := t.CurrentBet() + t.MinimumRaise()
+	return minimum, max(minimum, t.CurrentBet()+t.Pot()+toCall)
+}
+
+func (PotLimit) CanRaise(t *Table) bool {
+	return true
+}
+
+// フィックスドリミット。ドロー前はスモールベット、ドロー後はビッグベットの額ずつベットやレイズを行う
+type FixedLimit struct {
+	smallBet int
+	bigBet   int
+	// 1ラウンドで行えるベットとレイズの合計回数。0なら制限しない
+	raiseCap int
+}
+
+func NewFixedLimit(smallBet, bigBet, raiseCap int) (FixedLimit, error) {
+	if smallBet <= 0 || bigBet <= 0 {
+		return FixedLimit{}, fmt.Errorf("bet sizes must be positive")
+	}
+	if smallBet > bigBet {
+		return FixedLimit{}, fmt.Errorf("small bet must not be greater than big bet")
+	}
+	if raiseCap < 0 {
+		return FixedLimit{}, fmt.Errorf("raise cap must not be negative")
+	}
+	return FixedLimit{smallBet: smallBet, bigBet: bigBet, raiseCap: raiseCap}, nil
+}
+
+func (f FixedLimit) SmallBet() int {
+	return f.smallBet
+}
+
+func (f FixedLimit) BigBet() int {
+	return f.bigBet
+}
+
+func (f FixedLimit) RaiseCap() int {
+	return f.raiseCap
+}
+
+// 現在のラウンドのベットの単位
+func (f FixedLimit) betSize(t *Table) int {
+	if t.bettingRound >= 2 {
+		return f.bigBet
+	}
+	return f.smallBet
+}
+
+func (f FixedLimit) RaiseRange(t *Table, player *entity.Player) (int, int) {
+	raiseTo := t.CurrentBet() + f.betSize(t)
+	return raiseTo, raiseTo
+}
+
+// ヘッズアップではレイズの回数を制限しない
+func (f FixedLimit) CanRaise(t *Table) bool {
+	if f.raiseCap == 0 || len(t.RemainingPlayers()) <= 2 {
+		return true
+	}
+	return t.raiseCount < f.raiseCap
+}
+
+func (t *Table) BettingStructure() BettingStructure {
+	return t.bettingStructure
+}
+
+// ベッティングストラクチャーを変える。ハンドの途中では変えられない
+func (t *Table) SetBettingStructure(structure BettingStructure) error {
+	if structure == nil {
+		return fmt.Errorf("betting structure must not be nil")
+	}
+	if err := t.requirePhase("change betting structure", PhaseWaiting); err != nil {
+		return err
+	}
+	t.bettingStructure = structure
+	return nil
+}
+
+// playerがベットやレイズでこのラウンドの合計をいくらにできるか。スタックを超える額にはならない
+func (t *Table) RaiseRange(player *entity.Player) (minimum, maximum int) {
+	minimum, maximum = t.bettingStructure.RaiseRange(t, player)
+	allIn := player.Chips() + player.Stack()
+	return min(minimum, allIn), min(maximum, allIn)
+}
+
+// playerがベットやレイズをできるかどうか
+// すでにアクションしたプレイヤーは、フルレイズでアクションが再開されるまでレイズできない
+func (t *Table) canRaise(player *entity.Player) bool {
+	return !t.acted[player] && player.Stack() > t.CurrentBet()-player.Chips() && t.bettingStructure.CanRaise(t)
+}
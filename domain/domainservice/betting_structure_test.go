@@ -0,0 +1,251 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+func mustFixedLimit(t *testing.T, smallBet, bigBet, raiseCap int) FixedLimit {
+	t.Helper()
+	structure, err := NewFixedLimit(smallBet, bigBet, raiseCap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return structure
+}
+
+func TestTable_RaiseRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		structure   BettingStructure
+		wantMinimum int
+		wantMaximum int
+	}{
+		{
+			name:        "ノーリミットは直前のレイズ幅からスタック全額まで",
+			structure:   NoLimit{},
+			wantMinimum: 20,
+			wantMaximum: 100,
+		},
+		{
+			name:        "ポットリミットはコールした後のポットの額まで",
+			structure:   PotLimit{},
+			wantMinimum: 20,
+			wantMaximum: 35,
+		},
+		{
+			name:        "フィックスドリミットはドロー前にスモールベットの額だけ",
+			structure:   mustFixedLimit(t, 10, 20, 4),
+			wantMinimum: 20,
+			wantMaximum: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+			if err := table.SetBettingStructure(tt.structure); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.StartHand(); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.PostBlinds(); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.DealCards(); err != nil {
+				t.Fatal(err)
+			}
+			gotMinimum, gotMaximum := table.RaiseRange(players[0])
+			if gotMinimum != tt.wantMinimum || gotMaximum != tt.wantMaximum {
+				t.Errorf("Table.RaiseRange() = (%v, %v), want (%v, %v)", gotMinimum, gotMaximum, tt.wantMinimum, tt.wantMaximum)
+			}
+			if err := table.Act(players[0], Action{Type: ActionRaise, Amount: tt.wantMaximum + 1}); err == nil {
+				t.Errorf("Table.Act() above maximum error = nil, want error")
+			}
+			if err := table.Act(players[0], Action{Type: ActionRaise, Amount: tt.wantMaximum}); err != nil {
+				t.Errorf("Table.Act() at maximum error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPotLimit_AllIn(t *testing.T) {
+	table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if err := table.SetBettingStructure(PotLimit{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	// スタックがポットの額を超えるのでオールインはできない
+	want := []ActionType{ActionFold, ActionCall, ActionRaise}
+	if got := table.PermittedActions(players[0]); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PermittedActions() = %v, want %v", got, want)
+	}
+}
+
+func TestPotLimit_ShortBigBlind(t *testing.T) {
+	table, err := NewTableWithSeats("table", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBettingStructure(PotLimit{}); err != nil {
+		t.Fatal(err)
+	}
+	// ビッグブラインドのplayer2はスモールブラインドより少ない3でオールインになる
+	for seat, player := range []*entity.Player{newPlayerWithStack(t, "player0", 100), newPlayerWithStack(t, "player1", 100), newPlayerWithStack(t, "player2", 3)} {
+		if err := table.Join(seat, player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	// ポットの額が最低レイズ額に届かなくても、最低レイズ額まではレイズできる
+	actor := table.Actor()
+	if gotMinimum, gotMaximum := table.RaiseRange(actor); gotMinimum != 15 || gotMaximum != 15 {
+		t.Errorf("Table.RaiseRange() = (%v, %v), want (15, 15)", gotMinimum, gotMaximum)
+	}
+	if err := table.Act(actor, Action{Type: ActionRaise, Amount: 15}); err != nil {
+		t.Errorf("Table.Act() at minimum error = %v", err)
+	}
+}
+
+func TestFixedLimit_RaiseCap(t *testing.T) {
+	table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if err := table.SetBettingStructure(mustFixedLimit(t, 10, 20, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	// ビッグブラインドと3回のレイズで上限に達する
+	for i, seat := range []int{0, 1, 2} {
+		if err := table.Act(players[seat], Action{Type: ActionRaise, Amount: 20 + 10*i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []ActionType{ActionFold, ActionCall}
+	if got := table.PermittedActions(players[0]); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PermittedActions() after the cap = %v, want %v", got, want)
+	}
+	if err := table.Act(players[0], Action{Type: ActionFold}); err != nil {
+		t.Fatal(err)
+	}
+	// ヘッズアップになると上限はなくなる
+	if !containsAction(table.PermittedActions(players[1]), ActionRaise) {
+		t.Errorf("Table.PermittedActions() heads-up = %v, want raise to be permitted", table.PermittedActions(players[1]))
+	}
+}
+
+func TestFixedLimit_BigBetAfterDraw(t *testing.T) {
+	table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if err := table.SetBettingStructure(mustFixedLimit(t, 10, 20, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	for table.Phase() == PhaseBetting {
+		if err := table.Act(table.Actor(), PassiveDecider{}.DecideAction(table, table.Actor())); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for table.Phase() == PhaseDraw {
+		if err := table.Draw(table.Drawer(), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gotMinimum, gotMaximum := table.RaiseRange(players[1]); gotMinimum != 20 || gotMaximum != 20 {
+		t.Errorf("Table.RaiseRange() after draw = (%v, %v), want (20, 20)", gotMinimum, gotMaximum)
+	}
+}
+
+func TestNewFixedLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		smallBet int
+		bigBet   int
+		raiseCap int
+		wantErr  bool
+	}{
+		{
+			name:     "有効な額",
+			smallBet: 10,
+			bigBet:   20,
+			raiseCap: 4,
+			wantErr:  false,
+		},
+		{
+			name:     "上限なし",
+			smallBet: 10,
+			bigBet:   20,
+			raiseCap: 0,
+			wantErr:  false,
+		},
+		{
+			name:     "ベット額が0",
+			smallBet: 0,
+			bigBet:   20,
+			raiseCap: 4,
+			wantErr:  true,
+		},
+		{
+			name:     "スモールベットがビッグベットより大きい",
+			smallBet: 20,
+			bigBet:   10,
+			raiseCap: 4,
+			wantErr:  true,
+		},
+		{
+			name:     "上限が負",
+			smallBet: 10,
+			bigBet:   20,
+			raiseCap: -1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFixedLimit(tt.smallBet, tt.bigBet, tt.raiseCap); (err != nil) != tt.wantErr {
+				t.Errorf("NewFixedLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable_SetBettingStructure(t *testing.T) {
+	table, _ := newBettingTestTable(t)
+	if err := table.SetBettingStructure(PotLimit{}); err == nil {
+		t.Errorf("Table.SetBettingStructure() during a hand error = nil, want error")
+	}
+}
@@ -0,0 +1,359 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// 3人のテーブルでカードを配り、ドロー前のベッティングラウンドを始める
+func newBettingTestTable(t *testing.T) (*Table, map[int]*entity.Player) {
+	t.Helper()
+	table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	return table, players
+}
+
+func TestTable_Act(t *testing.T) {
+	tests := []struct {
+		name    string
+		seat    int
+		action  Action
+		wantErr bool
+	}{
+		{
+			name:    "手番のプレイヤーのコール",
+			seat:    0,
+			action:  Action{Type: ActionCall},
+			wantErr: false,
+		},
+		{
+			name:    "手番でないプレイヤーのアクション",
+			seat:    1,
+			action:  Action{Type: ActionCall},
+			wantErr: true,
+		},
+		{
+			name:    "ベットがある状況でのチェック",
+			seat:    0,
+			action:  Action{Type: ActionCheck},
+			wantErr: true,
+		},
+		{
+			name:    "最低額に満たないレイズ",
+			seat:    0,
+			action:  Action{Type: ActionRaise, Amount: 15},
+			wantErr: true,
+		},
+		{
+			name:    "最低額のレイズ",
+			seat:    0,
+			action:  Action{Type: ActionRaise, Amount: 20},
+			wantErr: false,
+		},
+		{
+			name:    "スタックを超えるレイズ",
+			seat:    0,
+			action:  Action{Type: ActionRaise, Amount: 200},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newBettingTestTable(t)
+			if err := table.Act(players[tt.seat], tt.action); (err != nil) != tt.wantErr {
+				t.Errorf("Table.Act() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable_Act_FoldToWinner(t *testing.T) {
+	table, players := newBettingTestTable(t)
+	if err := table.Act(players[0], Action{Type: ActionFold}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Act(players[1], Action{Type: ActionFold}); err != nil {
+		t.Fatal(err)
+	}
+	if table.Phase() != PhasePayout {
+		t.Fatalf("Table.Phase() = %v, want %v", table.Phase(), PhasePayout)
+	}
+	if _, err := table.JudgeWinner(); err == nil {
+		t.Errorf("Table.JudgeWinner() after everyone folded error = nil, want error")
+	}
+	remaining := table.RemainingPlayers()
+	if len(remaining) != 1 || remaining[0] != players[2] {
+		t.Fatalf("Table.RemainingPlayers() = %v, want only players[2]", remaining)
+	}
+	if err := table.DistributeChips(remaining); err != nil {
+		t.Fatal(err)
+	}
+	if players[2].Stack() != 105 {
+		t.Errorf("players[2].Stack() = %v, want 105", players[2].Stack())
+	}
+}
+
+func TestTable_Act_RaiseReopensAction(t *testing.T) {
+	table, players := newBettingTestTable(t)
+	if err := table.Act(players[0], Action{Type: ActionCall}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Act(players[1], Action{Type: ActionCall}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Act(players[2], Action{Type: ActionRaise, Amount: 30}); err != nil {
+		t.Fatal(err)
+	}
+	if table.MinimumRaise() != 20 {
+		t.Errorf("Table.MinimumRaise() = %v, want 20", table.MinimumRaise())
+	}
+	// レイズの後は、すでにアクションしたプレイヤーにも再び手番が回る
+	if table.Actor() != players[0] {
+		t.Errorf("Table.Actor() = %v, want players[0]", table.Actor())
+	}
+}
+
+type scriptedAction struct {
+	seat   int
+	action Action
+}
+
+func actAll(t *testing.T, table *Table, players []*entity.Player, actions []scriptedAction) {
+	t.Helper()
+	for _, a := range actions {
+		if err := table.Act(players[a.seat], a.action); err != nil {
+			t.Fatalf("seat %d %v: %v", a.seat, a.action.Type, err)
+		}
+	}
+}
+
+func TestTable_ReturnUncalledBet(t *testing.T) {
+	tests := []struct {
+		name       string
+		stacks     []int
+		actions    []scriptedAction
+		wantRefund int
+		wantPot    int
+	}{
+		{
+			name:   "全員がフォールドしたレイズ",
+			stacks: []int{100, 100, 100},
+			actions: []scriptedAction{
+				{seat: 0, action: Action{Type: ActionRaise, Amount: 50}},
+				{seat: 1, action: Action{Type: ActionFold}},
+				{seat: 2, action: Action{Type: ActionFold}},
+			},
+			wantRefund: 40,
+			wantPot:    25,
+		},
+		{
+			name:   "スタックが足りないオールインのコール",
+			stacks: []int{100, 100, 30},
+			actions: []scriptedAction{
+				{seat: 0, action: Action{Type: ActionRaise, Amount: 60}},
+				{seat: 1, action: Action{Type: ActionFold}},
+				{seat: 2, action: Action{Type: ActionAllIn}},
+			},
+			wantRefund: 30,
+			wantPot:    65,
+		},
+		{
+			name:   "ビッグブラインドへのウォーク",
+			stacks: []int{100, 100, 100},
+			actions: []scriptedAction{
+				{seat: 0, action: Action{Type: ActionFold}},
+				{seat: 1, action: Action{Type: ActionFold}},
+			},
+			wantRefund: 5,
+			wantPot:    10,
+		},
+		{
+			name:   "コールされたレイズは返さない",
+			stacks: []int{100, 100, 100},
+			actions: []scriptedAction{
+				{seat: 0, action: Action{Type: ActionRaise, Amount: 50}},
+				{seat: 1, action: Action{Type: ActionFold}},
+				{seat: 2, action: Action{Type: ActionCall}},
+			},
+			wantRefund: 0,
+			wantPot:    105,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newAllInTestTable(t, tt.stacks)
+			actAll(t, table, players, tt.actions)
+			refund := 0
+			for _, entry := range table.Ledger().EntriesForHand(1) {
+				if entry.Type == LedgerRefund {
+					refund += entry.Amount
+				}
+			}
+			if refund != tt.wantRefund {
+				t.Errorf("refund = %v, want %v", refund, tt.wantRefund)
+			}
+			if got := table.Pot(); got != tt.wantPot {
+				t.Errorf("Table.Pot() = %v, want %v", got, tt.wantPot)
+			}
+			if err := table.CheckConservation(); err != nil {
+				t.Errorf("Table.CheckConservation() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTable_Act_ShortAllIn(t *testing.T) {
+	tests := []struct {
+		name    string
+		stacks  []int
+		actions []scriptedAction
+		// 額の足りないオールインの後に手番が回るプレイヤーの席
+		seat             int
+		wantActions      []ActionType
+		wantMinimumRaise int
+	}{
+		{
+			name:   "額の足りないオールインはアクションを再開しない",
+			stacks: []int{100, 100, 25},
+			actions: []scriptedAction{
+				{seat: 0, action: Action{Type: ActionRaise, Amount: 20}},
+				{seat: 1, action: Action{Type: ActionCall}},
+				{seat: 2, action: Action{Type: ActionAllIn}},
+			},
+			seat:             0,
+			wantActions:      []ActionType{ActionFold, ActionCall},
+			wantMinimumRaise: 10,
+		},
+		{
+			name:   "フルレイズのオールインはアクションを再開する",
+			stacks: []int{100, 100, 30},
+			actions: []scriptedAction{
+				{seat: 0, action: Action{Type: ActionRaise, Amount: 20}},
+				{seat: 1, action: Action{Type: ActionCall}},
+				{seat: 2, action: Action{Type: ActionAllIn}},
+			},
+			seat:             0,
+			wantActions:      []ActionType{ActionFold, ActionCall, ActionRaise, ActionAllIn},
+			wantMinimumRaise: 10,
+		},
+		{
+			name:   "額の足りないオールインが重なってフルレイズになるとアクションを再開する",
+			stacks: []int{26, 30, 100, 100},
+			actions: []scriptedAction{
+				{seat: 3, action: Action{Type: ActionRaise, Amount: 20}},
+				{seat: 0, action: Action{Type: ActionAllIn}},
+				{seat: 1, action: Action{Type: ActionAllIn}},
+				{seat: 2, action: Action{Type: ActionCall}},
+			},
+			seat:             3,
+			wantActions:      []ActionType{ActionFold, ActionCall, ActionRaise, ActionAllIn},
+			wantMinimumRaise: 10,
+		},
+		{
+			name:   "額の足りないオールインが重なってもフルレイズに届かなければ再開しない",
+			stacks: []int{26, 28, 100, 100},
+			actions: []scriptedAction{
+				{seat: 3, action: Action{Type: ActionRaise, Amount: 20}},
+				{seat: 0, action: Action{Type: ActionAllIn}},
+				{seat: 1, action: Action{Type: ActionAllIn}},
+				{seat: 2, action: Action{Type: ActionCall}},
+			},
+			seat:             3,
+			wantActions:      []ActionType{ActionFold, ActionCall},
+			wantMinimumRaise: 10,
+		},
+		{
+			name:   "まだアクションしていないプレイヤーはレイズできる",
+			stacks: []int{100, 100, 25, 100},
+			actions: []scriptedAction{
+				{seat: 3, action: Action{Type: ActionCall}},
+				{seat: 0, action: Action{Type: ActionRaise, Amount: 20}},
+				{seat: 1, action: Action{Type: ActionFold}},
+				{seat: 2, action: Action{Type: ActionAllIn}},
+			},
+			seat:             3,
+			wantActions:      []ActionType{ActionFold, ActionCall, ActionRaise, ActionAllIn},
+			wantMinimumRaise: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newAllInTestTable(t, tt.stacks)
+			actAll(t, table, players, tt.actions)
+			if table.Actor() != players[tt.seat] {
+				t.Fatalf("Table.Actor() = %v, want seat %d", table.Actor(), tt.seat)
+			}
+			if got := table.PermittedActions(players[tt.seat]); !reflect.DeepEqual(got, tt.wantActions) {
+				t.Errorf("Table.PermittedActions() = %v, want %v", got, tt.wantActions)
+			}
+			if got := table.MinimumRaise(); got != tt.wantMinimumRaise {
+				t.Errorf("Table.MinimumRaise() = %v, want %v", got, tt.wantMinimumRaise)
+			}
+		})
+	}
+}
+
+// すべてのプレイヤーが常にオールインする
+func TestSession_MultiwayAllIn(t *testing.T) {
+	// スタックがすべて異なり、サイドポットが何層にもなる
+	tests := []struct {
+		name   string
+		stacks []int
+	}{
+		{
+			name:   "3人",
+			stacks: []int{40, 75, 120},
+		},
+		{
+			name:   "4人",
+			stacks: []int{40, 75, 120, 200},
+		},
+		{
+			name:   "6人でブラインドに足りないスタックがある",
+			stacks: []int{8, 30, 55, 90, 140, 300},
+		},
+		{
+			name:   "10人",
+			stacks: []int{15, 25, 40, 60, 85, 100, 150, 210, 280, 400},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := NewTableWithSeats("table", len(tt.stacks))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+				t.Fatal(err)
+			}
+			want := 0
+			for seat, stack := range tt.stacks {
+				if err := table.Join(seat, newPlayerWithStack(t, "player", stack)); err != nil {
+					t.Fatal(err)
+				}
+				want += stack
+			}
+			session := NewSession(table)
+			session.SetDecider(allInDecider{})
+			if _, err := session.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if got := totalStacks(table); got != want {
+				t.Errorf("total stacks = %v, want %v", got, want)
+			}
+			if err := table.CheckConservation(); err != nil {
+				t.Errorf("Table.CheckConservation() error = %v", err)
+			}
+		})
+	}
+}
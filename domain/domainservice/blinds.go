@@ -0,0 +1,268 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+type AnteType int
+
+const (
+	// アンティなし
+	NoAnte AnteType = iota
+	// 全員がアンティを支払う
+	PerPlayerAnte
+	// ビッグブラインドが全員分のアンティをまとめて支払う
+	BigBlindAnte
+	// ボタンが全員分のアンティをまとめて支払う
+	ButtonAnte
+)
+
+type ButtonRule int
+
+const (
+	// ビッグブラインドが必ず1人ずつ進み、ボタンやスモールブラインドが空席になることを許す
+	DeadButton ButtonRule = iota
+	// ボタンが必ず次のプレイヤーに進み、ブラインドはその後に続く
+	MovingButton
+)
+
+type Blinds struct {
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	AnteType   AnteType
+}
+
+// ファイブカードドローの伝統的な遊び方として、ブラインドなしで全員がアンティを支払う
+var defaultBlinds = Blinds{Ante: 10, AnteType: PerPlayerAnte}
+
+func (t *Table) Blinds() Blinds {
+	return t.blinds
+}
+
+func (t *Table) SetBlinds(blinds Blinds) error {
+	if blinds.SmallBlind < 0 || blinds.BigBlind < 0 || blinds.Ante < 0 {
+		return fmt.Errorf("blinds must not be negative")
+	}
+	if blinds.SmallBlind > blinds.BigBlind {
+		return fmt.Errorf("small blind must not be greater than big blind")
+	}
+	if blinds.Ante > 0 && blinds.AnteType == NoAnte {
+		return fmt.Errorf("ante type is not specified")
+	}
+	t.blinds = blinds
+	return nil
+}
+
+func (t *Table) SetButtonRule(rule ButtonRule) {
+	t.buttonRule = rule
+}
+
+func (t *Table) Button() int {
+	return t.button
+}
+
+func (t *Table) SmallBlindSeat() int {
+	return t.smallBlindSeat
+}
+
+func (t *Table) BigBlindSeat() int {
+	return t.bigBlindSeat
+}
+
+// 席のプレイヤーがハンドに参加できるかどうか。参加できない席のボタンやブラインドはデッドになる
+func (t *Table) isLive(seat int) bool {
+	return t.canTakeBigBlind(seat) && !t.seats[seat].waitingForBigBlind
+}
+
+// 席のプレイヤーがビッグブラインドを支払えるかどうか。ビッグブラインドを待っているプレイヤーを含む
+func (t *Table) canTakeBigBlind(seat int) bool {
+	if seat < 0 || seat >= len(t.seats) || t.seats[seat].IsEmpty() {
+		return false
+	}
+	player := t.seats[seat].player
+	return player.IsActive() && player.Stack() > 0
+}
+
+func (t *Table) countBigBlindCandidates() int {
+	count := 0
+	for seat := range t.seats {
+		if t.canTakeBigBlind(seat) {
+			count++
+		}
+	}
+	return count
+}
+
+// seatの次にいる、条件を満たすプレイヤーの席を返す
+func (t *Table) nextSeat(seat int, condition func(int) bool) int {
+	for i := 1; i <= len(t.seats); i++ {
+		next := (seat + i) % len(t.seats)
+		if condition(next) {
+			return next
+		}
+	}
+	return -1
+}
+
+// 次のハンドのボタンとブラインドの位置を決める
+func (t *Table) MoveButton() error {
+	if t.countBigBlindCandidates() < 2 {
+		return fmt.Errorf("at least 2 players with chips are required")
+	}
+	previousBigBlind := t.bigBlindSeat
+	if t.countBigBlindCandidates() == 2 {
+		t.moveHeadsUpButton()
+	} else if t.button < 0 || t.buttonRule == MovingButton {
+		start := t.button
+		if start < 0 {
+			start = len(t.seats) - 1
+		}
+		t.button = t.nextSeat(start, t.isLive)
+		t.smallBlindSeat = t.nextSeat(t.button, t.isLive)
+		t.bigBlindSeat = t.nextSeat(t.smallBlindSeat, t.canTakeBigBlind)
+	} else {
+		// デッドボタン: ビッグブラインドは次のプレイヤーへ進み、
+		// スモールブラインドとボタンは前のハンドのビッグブラインドとスモールブラインドの席に移る
+		t.button = t.smallBlindSeat
+		t.smallBlindSeat = t.bigBlindSeat
+		t.bigBlindSeat = t.nextSeat(t.bigBlindSeat, t.canTakeBigBlind)
+	}
+	if previousBigBlind >= 0 {
+		t.recordMissedBlinds(previousBigBlind)
+	}
+	return nil
+}
+
+// ヘッズアップではボタンがスモールブラインドを支払い、ビッグブラインドは毎ハンド交代する
+// ビッグブラインドを待っているプレイヤーも待たずに参加する
+func (t *Table) moveHeadsUpButton() {
+	if t.bigBlindSeat < 0 {
+		start := t.button
+		if start < 0 {
+			start = len(t.seats) - 1
+		}
+		t.bigBlindSeat = t.nextSeat(t.nextSeat(start, t.canTakeBigBlind), t.canTakeBigBlind)
+	} else {
+		t.bigBlindSeat = t.nextSeat(t.bigBlindSeat, t.canTakeBigBlind)
+	}
+	t.button = t.nextSeat(t.bigBlindSeat, t.canTakeBigBlind)
+	t.smallBlindSeat = t.button
+	t.seats[t.button].waitingForBigBlind = false
+	t.seats[t.bigBlindSeat].waitingForBigBlind = false
+}
+
+// シットアウト中にブラインドが通り過ぎた席を記録する
+func (t *Table) recordMissedBlinds(previousBigBlind int) {
+	for seat := (previousBigBlind + 1) % len(t.seats); seat != t.bigBlindSeat; seat = (seat + 1) % len(t.seats) {
+		if t.isSittingOut(seat) {
+			t.seats[seat].missedBigBlind = true
+		}
+	}
+	if t.isSittingOut(t.smallBlindSeat) {
+		t.seats[t.smallBlindSeat].missedSmallBlind = true
+	}
+}
+
+func (t *Table) isSittingOut(seat int) bool {
+	return seat >= 0 && !t.seats[seat].IsEmpty() && !t.seats[seat].player.IsActive()
+}
+
+// アンティとブラインドを支払う。スタックが足りないプレイヤーはオールインになる
+// 全員が支払うアンティはブラインドより先に、まとめて支払うアンティはブラインドの後に支払う
+// ハウスルールがあれば、その前後にハウスルールの強制ベットを支払う
+func (t *Table) PostBlinds() error {
+	if err := t.requirePhase("post blinds", PhasePosting); err != nil {
+		return err
+	}
+	t.missedBlinds = map[*Seat]missedBlinds{}
+	for _, seat := range t.seats {
+		if seat.missedSmallBlind || seat.missedBigBlind {
+			t.missedBlinds[seat] = missedBlinds{small: seat.missedSmallBlind, big: seat.missedBigBlind}
+		}
+	}
+	replaced, err := t.beforePosting()
+	if err != nil {
+		return err
+	}
+	if !replaced {
+		t.postBlinds()
+	}
+	if err := t.afterPosting(); err != nil {
+		return err
+	}
+	t.phase = PhaseDealing
+	return nil
+}
+
+func (t *Table) postBlinds() {
+	dealtIn := []*entity.Player{}
+	for seat := range t.seats {
+		if t.isLive(seat) {
+			dealtIn = append(dealtIn, t.seats[seat].player)
+		}
+	}
+	smallBlind, bigBlind, button := t.livePlayerAt(t.smallBlindSeat), t.livePlayerAt(t.bigBlindSeat), t.livePlayerAt(t.button)
+	if t.blinds.AnteType == PerPlayerAnte {
+		for _, player := range dealtIn {
+			t.postAnte(player, t.blinds.Ante)
+		}
+	}
+	if smallBlind != nil && smallBlind != bigBlind {
+		t.record(LedgerBlind, smallBlind, smallBlind.PostBlind(t.blinds.SmallBlind))
+	}
+	if bigBlind != nil {
+		t.record(LedgerBlind, bigBlind, bigBlind.PostBlind(t.blinds.BigBlind))
+	}
+	t.postMissedBlinds()
+	switch t.blinds.AnteType {
+	case BigBlindAnte:
+		if bigBlind != nil {
+			t.postAnte(bigBlind, t.blinds.Ante*len(dealtIn))
+		}
+	case ButtonAnte:
+		// デッドボタンの場合はアンティを集めない
+		if button != nil {
+			t.postAnte(button, t.blinds.Ante*len(dealtIn))
+		}
+	}
+}
+
+// 席にいるチップを持ったプレイヤーを返す。デッドの席ならnil
+func (t *Table) livePlayerAt(seat int) *entity.Player {
+	if !t.isLive(seat) {
+		return nil
+	}
+	return t.seats[seat].player
+}
+
+// シットアウトから復帰したプレイヤーが、支払わなかったブラインドを支払う
+// ビッグブラインドは掛け金として、スモールブラインドはデッドマネーとしてポットに入れる
+func (t *Table) postMissedBlinds() {
+	for seatNumber, seat := range t.seats {
+		if !t.isLive(seatNumber) || (!seat.missedSmallBlind && !seat.missedBigBlind) {
+			continue
+		}
+		if seatNumber != t.bigBlindSeat {
+			if seat.missedBigBlind {
+				t.record(LedgerBlind, seat.player, seat.player.PostBlind(t.blinds.BigBlind-seat.player.Chips()))
+			}
+			t.postDeadMoney(LedgerBlind, seat.player, t.blinds.SmallBlind)
+		}
+		seat.missedSmallBlind = false
+		seat.missedBigBlind = false
+	}
+}
+
+func (t *Table) postAnte(player *entity.Player, amount int) {
+	t.postDeadMoney(LedgerAnte, player, amount)
+}
+
+// 掛け金にせずに直接ポットに入れる。デッドマネーはメインポットに入る
+func (t *Table) postDeadMoney(entryType LedgerEntryType, player *entity.Player, amount int) {
+	posted := t.record(entryType, player, player.PostAnte(amount))
+	t.pot += posted
+	t.deadMoney[player] += posted
+}
@@ -0,0 +1,338 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+func newBlindsTestTable(t *testing.T, stacks []int) *Table {
+	t.Helper()
+	players := []*entity.Player{}
+	for _, stack := range stacks {
+		players = append(players, newPlayerWithStack(t, "player", stack))
+	}
+	table, err := NewTable("table", players)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+// プレイヤーのチップを全て取り除き、バストした状態にする
+func bust(player *entity.Player) {
+	player.PostAnte(player.Stack())
+}
+
+func TestTable_MoveButton(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   ButtonRule
+		busted []int
+		// 2ハンド目のボタン、スモールブラインド、ビッグブラインドの席
+		want []int
+	}{
+		{
+			name:   "デッドボタン/誰もバストしない",
+			rule:   DeadButton,
+			busted: nil,
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "デッドボタン/スモールブラインドがバストするとボタンがデッドになる",
+			rule:   DeadButton,
+			busted: []int{1},
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "デッドボタン/ビッグブラインドがバストするとスモールブラインドがデッドになる",
+			rule:   DeadButton,
+			busted: []int{2},
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "デッドボタン/次のビッグブラインドがバストすると飛ばす",
+			rule:   DeadButton,
+			busted: []int{3},
+			want:   []int{1, 2, 0},
+		},
+		{
+			name:   "ムービングボタン/バストしたプレイヤーを飛ばしてボタンが進む",
+			rule:   MovingButton,
+			busted: []int{1},
+			want:   []int{2, 3, 0},
+		},
+		{
+			name:   "ヘッズアップになるとボタンがスモールブラインドを支払う",
+			rule:   DeadButton,
+			busted: []int{1, 3},
+			want:   []int{2, 2, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newBlindsTestTable(t, []int{100, 100, 100, 100})
+			table.SetButtonRule(tt.rule)
+			if err := table.MoveButton(); err != nil {
+				t.Fatal(err)
+			}
+			if got := []int{table.Button(), table.SmallBlindSeat(), table.BigBlindSeat()}; !reflect.DeepEqual(got, []int{0, 1, 2}) {
+				t.Fatalf("first hand positions = %v, want [0 1 2]", got)
+			}
+			for _, seat := range tt.busted {
+				bust(table.Players()[seat])
+			}
+			if err := table.MoveButton(); err != nil {
+				t.Fatal(err)
+			}
+			if got := []int{table.Button(), table.SmallBlindSeat(), table.BigBlindSeat()}; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("positions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_HeadsUp(t *testing.T) {
+	table := newBlindsTestTable(t, []int{100, 100})
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	players := table.Players()
+	for hand := 0; hand < 2; hand++ {
+		button, bigBlind := players[hand%2], players[(hand+1)%2]
+		if err := table.StartHand(); err != nil {
+			t.Fatal(err)
+		}
+		if table.Button() != table.SmallBlindSeat() || table.Button() == table.BigBlindSeat() {
+			t.Fatalf("hand %d: button %d must post the small blind and big blind is %d", hand+1, table.Button(), table.BigBlindSeat())
+		}
+		if err := table.PostBlinds(); err != nil {
+			t.Fatal(err)
+		}
+		if button.Chips() != 5 || bigBlind.Chips() != 10 {
+			t.Errorf("hand %d: button chips = %v, big blind chips = %v, want 5, 10", hand+1, button.Chips(), bigBlind.Chips())
+		}
+		if err := table.DealCards(); err != nil {
+			t.Fatal(err)
+		}
+		// ドロー前はボタンが先に、ドロー後はビッグブラインドが先にアクションする
+		if table.Actor() != button {
+			t.Errorf("hand %d: first to act before the draw is not the button", hand+1)
+		}
+		if err := table.Act(button, Action{Type: ActionCall}); err != nil {
+			t.Fatal(err)
+		}
+		if err := table.Act(bigBlind, Action{Type: ActionCheck}); err != nil {
+			t.Fatal(err)
+		}
+		for _, player := range []*entity.Player{bigBlind, button} {
+			if err := table.Draw(player, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if table.Actor() != bigBlind {
+			t.Errorf("hand %d: first to act after the draw is not the big blind", hand+1)
+		}
+		finishHand(t, table)
+	}
+}
+
+func TestTable_MoveButton_NotEnoughPlayers(t *testing.T) {
+	table := newBlindsTestTable(t, []int{100, 0})
+	if err := table.MoveButton(); err == nil {
+		t.Errorf("Table.MoveButton() error = nil, want error")
+	}
+}
+
+func TestTable_PostBlinds(t *testing.T) {
+	tests := []struct {
+		name      string
+		blinds    Blinds
+		stacks    []int
+		busted    []int
+		wantChips []int
+		wantStack []int
+		wantPot   int
+	}{
+		{
+			name:      "ブラインドのみ",
+			blinds:    Blinds{SmallBlind: 5, BigBlind: 10},
+			stacks:    []int{100, 100, 100, 100},
+			wantChips: []int{0, 5, 10, 0},
+			wantStack: []int{100, 95, 90, 100},
+			wantPot:   15,
+		},
+		{
+			name:      "全員がアンティを支払う",
+			blinds:    Blinds{SmallBlind: 5, BigBlind: 10, Ante: 1, AnteType: PerPlayerAnte},
+			stacks:    []int{100, 100, 100, 100},
+			wantChips: []int{0, 5, 10, 0},
+			wantStack: []int{99, 94, 89, 99},
+			wantPot:   19,
+		},
+		{
+			name:      "ビッグブラインドアンティ",
+			blinds:    Blinds{SmallBlind: 5, BigBlind: 10, Ante: 10, AnteType: BigBlindAnte},
+			stacks:    []int{100, 100, 100, 100},
+			wantChips: []int{0, 5, 10, 0},
+			wantStack: []int{100, 95, 50, 100},
+			wantPot:   55,
+		},
+		{
+			name:      "ビッグブラインドアンティはブラインドを優先する",
+			blinds:    Blinds{SmallBlind: 5, BigBlind: 10, Ante: 10, AnteType: BigBlindAnte},
+			stacks:    []int{100, 100, 15, 100},
+			wantChips: []int{0, 5, 10, 0},
+			wantStack: []int{100, 95, 0, 100},
+			wantPot:   20,
+		},
+		{
+			name:      "ボタンアンティ",
+			blinds:    Blinds{SmallBlind: 5, BigBlind: 10, Ante: 5, AnteType: ButtonAnte},
+			stacks:    []int{100, 100, 100, 100},
+			wantChips: []int{0, 5, 10, 0},
+			wantStack: []int{80, 95, 90, 100},
+			wantPot:   35,
+		},
+		{
+			name:      "スタックが足りないビッグブラインドはオールイン",
+			blinds:    Blinds{SmallBlind: 5, BigBlind: 10},
+			stacks:    []int{100, 100, 6, 100},
+			wantChips: []int{0, 5, 6, 0},
+			wantStack: []int{100, 95, 0, 100},
+			wantPot:   11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newBlindsTestTable(t, tt.stacks)
+			if err := table.SetBlinds(tt.blinds); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.StartHand(); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.PostBlinds(); err != nil {
+				t.Fatal(err)
+			}
+			gotChips, gotStack := []int{}, []int{}
+			for _, seat := range table.Seats()[:len(tt.stacks)] {
+				gotChips = append(gotChips, seat.Player().Chips())
+				gotStack = append(gotStack, seat.Player().Stack())
+			}
+			if !reflect.DeepEqual(gotChips, tt.wantChips) {
+				t.Errorf("chips = %v, want %v", gotChips, tt.wantChips)
+			}
+			if !reflect.DeepEqual(gotStack, tt.wantStack) {
+				t.Errorf("stacks = %v, want %v", gotStack, tt.wantStack)
+			}
+			if got := table.Pot(); got != tt.wantPot {
+				t.Errorf("Table.Pot() = %v, want %v", got, tt.wantPot)
+			}
+		})
+	}
+}
+
+func TestTable_PostBlinds_DeadSmallBlind(t *testing.T) {
+	table := newBlindsTestTable(t, []int{100, 100, 100, 100})
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.MoveButton(); err != nil {
+		t.Fatal(err)
+	}
+	bust(table.Players()[2])
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Pot(); got != 10 {
+		t.Errorf("Table.Pot() = %v, want 10", got)
+	}
+}
+
+func TestTable_SetBlinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		blinds  Blinds
+		wantErr bool
+	}{
+		{
+			name:    "正常",
+			blinds:  Blinds{SmallBlind: 5, BigBlind: 10, Ante: 1, AnteType: PerPlayerAnte},
+			wantErr: false,
+		},
+		{
+			name:    "スモールブラインドがビッグブラインドより大きい",
+			blinds:  Blinds{SmallBlind: 20, BigBlind: 10},
+			wantErr: true,
+		},
+		{
+			name:    "負のアンティ",
+			blinds:  Blinds{SmallBlind: 5, BigBlind: 10, Ante: -1, AnteType: PerPlayerAnte},
+			wantErr: true,
+		},
+		{
+			name:    "アンティの種類が未指定",
+			blinds:  Blinds{SmallBlind: 5, BigBlind: 10, Ante: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newBlindsTestTable(t, []int{100, 100})
+			if err := table.SetBlinds(tt.blinds); (err != nil) != tt.wantErr {
+				t.Errorf("Table.SetBlinds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable_DistributeChips(t *testing.T) {
+	table := newBlindsTestTable(t, []int{100, 100, 100})
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10, Ante: 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	players := []*entity.Player{table.Seats()[0].Player(), table.Seats()[1].Player(), table.Seats()[2].Player()}
+	if got := table.CalculateTotalChips(); got != 15 {
+		t.Errorf("Table.CalculateTotalChips() = %v, want 15", got)
+	}
+	if err := table.DistributeChips(players[:1]); err == nil {
+		t.Errorf("Table.DistributeChips() before payout error = nil, want error")
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	// ボタンがコールし、スモールブラインドがフォールドしたポット25を2人で分ける
+	for _, act := range []struct {
+		player *entity.Player
+		action Action
+	}{
+		{player: players[0], action: Action{Type: ActionCall}},
+		{player: players[1], action: Action{Type: ActionFold}},
+		{player: players[2], action: Action{Type: ActionCheck}},
+	} {
+		if err := table.Act(act.player, act.action); err != nil {
+			t.Fatal(err)
+		}
+	}
+	table.phase = PhasePayout
+	if err := table.DistributeChips([]*entity.Player{players[0], players[2]}); err != nil {
+		t.Fatal(err)
+	}
+	if got := []int{players[0].Stack(), players[1].Stack(), players[2].Stack()}; !reflect.DeepEqual(got, []int{103, 95, 102}) {
+		t.Errorf("stacks = %v, want [103 95 102]", got)
+	}
+	if got := table.Pot(); got != 0 {
+		t.Errorf("Table.Pot() = %v, want 0", got)
+	}
+}
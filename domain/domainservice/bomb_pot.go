@@ -0,0 +1,69 @@
+package domainservice
+
+import (
+	"fmt"
+)
+
+// ボムポットのハウスルール。ボムポットのハンドでは、ブラインドの代わりに全員が同じ額のアンティを支払い、
+// ドロー前のベッティングラウンドを行わずにドローから始める
+type BombPot struct {
+	ante int
+	// このハンド数ごとにボムポットにする。0ならScheduleしたハンドだけ
+	every     int
+	scheduled bool
+	// ボムポットにしたハンドの番号
+	handNumber int
+}
+
+func NewBombPot(ante, every int) (*BombPot, error) {
+	if ante <= 0 {
+		return nil, fmt.Errorf("bomb pot ante must be positive")
+	}
+	if every < 0 {
+		return nil, fmt.Errorf("bomb pot interval must not be negative")
+	}
+	return &BombPot{ante: ante, every: every}, nil
+}
+
+func (b *BombPot) Name() string {
+	return "bomb pot"
+}
+
+func (b *BombPot) Ante() int {
+	return b.ante
+}
+
+// 次のハンドをボムポットにする
+func (b *BombPot) Schedule() {
+	b.scheduled = true
+}
+
+// テーブルの現在のハンドがボムポットかどうか
+func (b *BombPot) IsBombPot(t *Table) bool {
+	return b.handNumber > 0 && b.handNumber == t.HandNumber()
+}
+
+// ボムポットのハンドであれば、ブラインドの代わりに全員がアンティを支払う
+// ミスディールで配り直す場合も、同じハンドであればボムポットのまま支払い直す
+func (b *BombPot) BeforePosting(t *Table) (bool, error) {
+	if !b.scheduled && !b.IsBombPot(t) && (b.every == 0 || t.HandNumber()%b.every != 0) {
+		return false, nil
+	}
+	b.scheduled = false
+	b.handNumber = t.HandNumber()
+	for _, player := range t.Players() {
+		if err := t.PostAnte(player, b.ante); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (b *BombPot) AfterPosting(t *Table) error {
+	return nil
+}
+
+// ボムポットのハンドではドロー前のベッティングラウンドを行わない
+func (b *BombPot) AfterDealing(t *Table) (bool, error) {
+	return !b.IsBombPot(t), nil
+}
@@ -0,0 +1,111 @@
+package domainservice
+
+import (
+	"testing"
+)
+
+func TestNewBombPot(t *testing.T) {
+	tests := []struct {
+		name    string
+		ante    int
+		every   int
+		wantErr bool
+	}{
+		{name: "アンティと間隔を指定する", ante: 10, every: 5},
+		{name: "間隔が0なら指定したハンドだけ", ante: 10, every: 0},
+		{name: "アンティが0", ante: 0, every: 5, wantErr: true},
+		{name: "間隔が負", ante: 10, every: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBombPot(tt.ante, tt.every)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBombPot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBombPot(t *testing.T) {
+	bombPot, err := NewBombPot(20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bombPot.Schedule()
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100}, bombPot)
+	if !bombPot.IsBombPot(table) {
+		t.Fatalf("BombPot.IsBombPot() = false, want true")
+	}
+	// ブラインドの代わりに全員がアンティを支払う
+	for _, player := range players {
+		if player.Stack() != 80 || player.Chips() != 0 {
+			t.Errorf("%s stack = %v, chips = %v, want 80 and 0", player.Name(), player.Stack(), player.Chips())
+		}
+	}
+	if got := table.Pot(); got != 60 {
+		t.Errorf("Table.Pot() = %v, want 60", got)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	// ドロー前のベッティングラウンドを行わずにドローから始める
+	if table.Phase() != PhaseDraw {
+		t.Errorf("Table.Phase() = %v, want %v", table.Phase(), PhaseDraw)
+	}
+	for table.Phase() == PhaseDraw {
+		if err := table.Draw(table.Drawer(), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if table.Phase() != PhaseBetting || table.BettingRound() != 2 {
+		t.Errorf("Table.Phase() = %v in round %v, want %v in round 2", table.Phase(), table.BettingRound(), PhaseBetting)
+	}
+}
+
+func TestBombPot_Every(t *testing.T) {
+	bombPot, err := NewBombPot(10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := NewTableWithSeats("table", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	for seat := 0; seat < 3; seat++ {
+		if err := table.Join(seat, newPlayerWithStack(t, "player", 1000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.AddHouseRule(bombPot); err != nil {
+		t.Fatal(err)
+	}
+	session := NewSession(table)
+	session.SetDecider(PassiveDecider{})
+	for hand := 1; hand <= 4; hand++ {
+		if _, err := session.PlayHand(); err != nil {
+			t.Fatal(err)
+		}
+		blinds, antes := 0, 0
+		for _, entry := range table.Ledger().EntriesForHand(hand) {
+			switch entry.Type {
+			case LedgerBlind:
+				blinds += entry.Amount
+			case LedgerAnte:
+				antes += entry.Amount
+			}
+		}
+		wantBlinds, wantAntes := 15, 0
+		if hand%2 == 0 {
+			wantBlinds, wantAntes = 0, 30
+		}
+		if blinds != wantBlinds || antes != wantAntes {
+			t.Errorf("hand %d blinds = %v, antes = %v, want %v and %v", hand, blinds, antes, wantBlinds, wantAntes)
+		}
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package domainservice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// キャッシュゲームのバイインの制限。額はビッグブラインドの何倍かで指定する
+type BuyInLimits struct {
+	// 0なら下限なし
+	MinimumBigBlinds int
+	// 0なら上限なし
+	MaximumBigBlinds int
+	// キャッシュアウトしてからこの時間内に同じテーブルに戻る場合は、キャッシュアウトしたときのスタック以上で座らなければならない (ラットホール防止)
+	RatholeWindow time.Duration
+}
+
+// チップ1枚の金額の初期値
+var defaultChipValue = valueobject.MinorUnit(valueobject.DefaultCurrency)
+
+type cashOut struct {
+	stack int
+	at    time.Time
+}
+
+// チップ1枚の金額
+func (t *Table) ChipValue() valueobject.Money {
+	return t.chipValue
+}
+
+func (t *Table) SetChipValue(value valueobject.Money) error {
+	if value.IsZero() {
+		return fmt.Errorf("chip value must be positive")
+	}
+	t.chipValue = value
+	return nil
+}
+
+// chips枚のチップの金額
+func (t *Table) ChipsToMoney(chips int) (valueobject.Money, error) {
+	if chips < 0 {
+		return valueobject.Money{}, fmt.Errorf("chips must not be negative")
+	}
+	return t.chipValue.Multiply(int64(chips))
+}
+
+func (t *Table) BuyInLimits() BuyInLimits {
+	return t.buyInLimits
+}
+
+func (t *Table) SetBuyInLimits(limits BuyInLimits) error {
+	if limits.MinimumBigBlinds < 0 || limits.MaximumBigBlinds < 0 || limits.RatholeWindow < 0 {
+		return fmt.Errorf("buy-in limits must not be negative")
+	}
+	if limits.MaximumBigBlinds > 0 && limits.MinimumBigBlinds > limits.MaximumBigBlinds {
+		return fmt.Errorf("minimum buy-in must not be greater than maximum buy-in")
+	}
+	t.buyInLimits = limits
+	return nil
+}
+
+// プレイヤーが座るときに持ち込めるチップの範囲。maximumが0なら上限なし
+// ラットホール防止の時間内であれば、キャッシュアウトしたときのスタックを下限とし、上限もそれより小さくしない
+func (t *Table) BuyInRange(player *entity.Player) (minimum, maximum int) {
+	minimum = t.buyInLimits.MinimumBigBlinds * t.blinds.BigBlind
+	maximum = t.buyInLimits.MaximumBigBlinds * t.blinds.BigBlind
+	previous, ok := t.cashOuts[player]
+	if !ok || t.clock().Sub(previous.at) >= t.buyInLimits.RatholeWindow {
+		return minimum, maximum
+	}
+	minimum = max(minimum, previous.stack)
+	if maximum > 0 {
+		maximum = max(maximum, previous.stack)
+	}
+	return minimum, maximum
+}
+
+// 所持金でamount枚のチップを買って席に着く
+func (t *Table) SitDown(seatNumber int, player *entity.Player, amount int) error {
+	minimum, maximum := t.BuyInRange(player)
+	if amount < minimum || (maximum > 0 && amount > maximum) {
+		return fmt.Errorf("buy-in must be between %d and %d", minimum, maximum)
+	}
+	cost, err := t.ChipsToMoney(amount)
+	if err != nil {
+		return err
+	}
+	if _, err := player.Money().Subtract(cost); err != nil {
+		return err
+	}
+	if err := t.Join(seatNumber, player); err != nil {
+		return err
+	}
+	return t.buyChips(player, amount)
+}
+
+// ハンドの合間に、所持金でチップを買い足す。買い足した後のスタックは上限を超えられない
+func (t *Table) TopUp(player *entity.Player, amount int) error {
+	if t.IsHandInProgress() {
+		return fmt.Errorf("cannot top up during a hand")
+	}
+	if _, err := t.SeatOf(player); err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return fmt.Errorf("top-up amount must be positive")
+	}
+	if maximum := t.buyInLimits.MaximumBigBlinds * t.blinds.BigBlind; maximum > 0 && player.Stack()+amount > maximum {
+		return fmt.Errorf("stack must not exceed the maximum buy-in of %d", maximum)
+	}
+	return t.buyChips(player, amount)
+}
+
+func (t *Table) buyChips(player *entity.Player, amount int) error {
+	cost, err := t.ChipsToMoney(amount)
+	if err != nil {
+		return err
+	}
+	if err := player.BuyChips(cost, amount); err != nil {
+		return err
+	}
+	t.record(LedgerBuyIn, player, amount)
+	return nil
+}
+
+// 席を立ち、スタックをすべて所持金に戻して、戻した金額を返す。ハンド中はできない
+// 席にいる間にチップの一部だけを持ち帰ることはできない
+func (t *Table) CashOut(player *entity.Player) (valueobject.Money, error) {
+	if t.isInCurrentHand(player) {
+		return valueobject.Money{}, fmt.Errorf("cannot cash out during a hand")
+	}
+	seatNumber, err := t.SeatOf(player)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	stack := player.Stack()
+	t.removePlayer(seatNumber)
+	proceeds, err := player.CashOut(t.chipValue)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	t.cashOuts[player] = cashOut{stack: stack, at: t.clock()}
+	return proceeds, nil
+}
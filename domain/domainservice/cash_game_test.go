@@ -0,0 +1,237 @@
+package domainservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ブラインド5/10、バイインが20BBから100BBのキャッシュゲームのテーブルを作る
+func newCashGameTestTable(t *testing.T, window time.Duration) *Table {
+	t.Helper()
+	table, err := NewTableWithSeats("cash", 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBuyInLimits(BuyInLimits{MinimumBigBlinds: 20, MaximumBigBlinds: 100, RatholeWindow: window}); err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestTable_SetBuyInLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  BuyInLimits
+		wantErr bool
+	}{
+		{
+			name:    "上限なし",
+			limits:  BuyInLimits{MinimumBigBlinds: 40},
+			wantErr: false,
+		},
+		{
+			name:    "下限が上限より大きい",
+			limits:  BuyInLimits{MinimumBigBlinds: 100, MaximumBigBlinds: 40},
+			wantErr: true,
+		},
+		{
+			name:    "負の時間",
+			limits:  BuyInLimits{RatholeWindow: -time.Minute},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newBlindsTestTable(t, []int{100, 100})
+			if err := table.SetBuyInLimits(tt.limits); (err != nil) != tt.wantErr {
+				t.Errorf("Table.SetBuyInLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable_SitDown(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   int
+		amount  int
+		wantErr bool
+	}{
+		{
+			name:    "下限ちょうど",
+			money:   1000,
+			amount:  200,
+			wantErr: false,
+		},
+		{
+			name:    "上限ちょうど",
+			money:   1000,
+			amount:  1000,
+			wantErr: false,
+		},
+		{
+			name:    "下限未満",
+			money:   1000,
+			amount:  190,
+			wantErr: true,
+		},
+		{
+			name:    "上限超過",
+			money:   2000,
+			amount:  1010,
+			wantErr: true,
+		},
+		{
+			name:    "所持金が足りない",
+			money:   500,
+			amount:  600,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newCashGameTestTable(t, 0)
+			player := newTestPlayer(t, "alice", tt.money)
+			err := table.SitDown(0, player, tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Table.SitDown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !table.Seats()[0].IsEmpty() || player.Money().Amount() != int64(tt.money) {
+					t.Errorf("failed buy-in must not seat the player or take money")
+				}
+				return
+			}
+			if player.Stack() != tt.amount || player.Money().Amount() != int64(tt.money-tt.amount) {
+				t.Errorf("stack, money = %v, %v, want %v, %v", player.Stack(), player.Money(), tt.amount, tt.money-tt.amount)
+			}
+			if err := table.CheckConservation(); err != nil {
+				t.Errorf("Table.CheckConservation() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTable_TopUp(t *testing.T) {
+	table := newCashGameTestTable(t, 0)
+	alice, bob := newTestPlayer(t, "alice", 2000), newTestPlayer(t, "bob", 2000)
+	if err := table.SitDown(0, alice, 500); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SitDown(1, bob, 500); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.TopUp(alice, 600); err == nil {
+		t.Errorf("Table.TopUp() over the maximum error = nil, want error")
+	}
+	if err := table.TopUp(alice, 500); err != nil {
+		t.Fatal(err)
+	}
+	if alice.Stack() != 1000 || alice.Money().Amount() != 1000 {
+		t.Errorf("stack, money = %v, %v, want 1000, 1000", alice.Stack(), alice.Money())
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.TopUp(bob, 100); err == nil {
+		t.Errorf("Table.TopUp() during a hand error = nil, want error")
+	}
+	finishHand(t, table)
+	if err := table.TopUp(bob, 100); err != nil {
+		t.Errorf("Table.TopUp() between hands error = %v", err)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+}
+
+func TestTable_CashOut(t *testing.T) {
+	table := newCashGameTestTable(t, time.Hour)
+	now := time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC)
+	table.clock = func() time.Time { return now }
+	alice, bob := newTestPlayer(t, "alice", 2000), newTestPlayer(t, "bob", 2000)
+	if err := table.SitDown(0, alice, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SitDown(1, bob, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.CashOut(alice); err == nil {
+		t.Errorf("Table.CashOut() during a hand error = nil, want error")
+	}
+	finishHand(t, table)
+	stack := alice.Stack()
+	amount, err := table.CashOut(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Amount() != int64(stack) || alice.Stack() != 0 || alice.Money().Amount() != int64(1000+stack) {
+		t.Errorf("Table.CashOut() = %v, stack = %v, money = %v, want %v, 0, %v", amount, alice.Stack(), alice.Money(), stack, 1000+stack)
+	}
+	if !table.Seats()[0].IsEmpty() {
+		t.Errorf("seat 0 should be empty after cashing out")
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+	// 時間内に戻る場合は、キャッシュアウトしたときのスタックより少なく座れない
+	now = now.Add(30 * time.Minute)
+	if minimum, _ := table.BuyInRange(alice); minimum != stack {
+		t.Errorf("Table.BuyInRange() minimum = %v, want %v", minimum, stack)
+	}
+	if err := table.SitDown(0, alice, stack-10); err == nil {
+		t.Errorf("Table.SitDown() below the previous stack error = nil, want error")
+	}
+	now = now.Add(30 * time.Minute)
+	if minimum, _ := table.BuyInRange(alice); minimum != 200 {
+		t.Errorf("Table.BuyInRange() minimum after the window = %v, want 200", minimum)
+	}
+	if err := table.SitDown(0, alice, 200); err != nil {
+		t.Errorf("Table.SitDown() after the window error = %v", err)
+	}
+}
+
+func TestTable_ChipValue(t *testing.T) {
+	table := newCashGameTestTable(t, time.Hour)
+	// チップ1枚を25セントとするテーブル
+	chipValue, err := valueobject.NewMoney(25, valueobject.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetChipValue(chipValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetChipValue(valueobject.ZeroMoney(valueobject.USD)); err == nil {
+		t.Errorf("Table.SetChipValue() with zero error = nil, want error")
+	}
+	wallet, err := valueobject.NewMoney(50000, valueobject.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := entity.NewPlayerWithMoney("alice", wallet)
+	if err := table.SitDown(0, alice, 400); err != nil {
+		t.Fatal(err)
+	}
+	if got := alice.Money().String(); got != "$400.00" {
+		t.Errorf("money after buying 400 chips = %v, want $400.00", got)
+	}
+	if err := table.SitDown(1, newTestPlayer(t, "bob", 100000), 400); err == nil {
+		t.Errorf("Table.SitDown() with another currency error = nil, want error")
+	}
+	proceeds, err := table.CashOut(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proceeds.String() != "$100.00" || alice.Money().String() != "$500.00" {
+		t.Errorf("Table.CashOut() = %v, money = %v, want $100.00, $500.00", proceeds, alice.Money())
+	}
+}
@@ -0,0 +1,97 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// 1人に配るカードの枚数
+const cardsPerHand = 5
+
+// 1組のデッキのカードの枚数
+const cardsPerDeck = 52
+
+// 山札が足りなくなったときの扱い
+type DeckExhaustion int
+
+const (
+	// エラーにする
+	ErrorOnExhaustion DeckExhaustion = iota
+	// 捨てられたカードをシャッフルして山札に加える
+	ReshuffleMuck
+	// 2組目のデッキをシャッフルして山札に加える。人数が多く、配るだけで52枚を超える遊び方に使う
+	AddSecondDeck
+)
+
+func (d DeckExhaustion) String() string {
+	switch d {
+	case ErrorOnExhaustion:
+		return "error"
+	case ReshuffleMuck:
+		return "reshuffle muck"
+	case AddSecondDeck:
+		return "second deck"
+	default:
+		return "unknown"
+	}
+}
+
+func (t *Table) DeckExhaustion() DeckExhaustion {
+	return t.deckExhaustion
+}
+
+// 山札が足りなくなったときの扱いを決める。ハンド中は変えられない
+func (t *Table) SetDeckExhaustion(rule DeckExhaustion) error {
+	if t.IsHandInProgress() {
+		return fmt.Errorf("cannot change deck exhaustion rule during a hand")
+	}
+	if rule < ErrorOnExhaustion || rule > AddSecondDeck {
+		return fmt.Errorf("unknown deck exhaustion rule %d", rule)
+	}
+	previous := t.deckExhaustion
+	t.deckExhaustion = rule
+	if players := len(t.seatedPlayers()); players*cardsPerHand > t.deckCapacity() {
+		t.deckExhaustion = previous
+		return fmt.Errorf("not enough cards in deck to deal to %d players", players)
+	}
+	return nil
+}
+
+// 最初に配るときに使えるカードの枚数。捨てられたカードはまだないため、2組目のデッキを使う場合だけ52枚を超える
+func (t *Table) deckCapacity() int {
+	if t.deckExhaustion == AddSecondDeck {
+		return 2 * cardsPerDeck
+	}
+	return cardsPerDeck
+}
+
+// 山札の上からn枚を取る。足りなければ決められた扱いで山札を補い、それでも足りなければエラーを返す
+func (t *Table) takeCards(n int) ([]*valueobject.Card, error) {
+	if err := t.ensureCards(n); err != nil {
+		return nil, err
+	}
+	cards := t.deck[:n:n]
+	t.deck = t.deck[n:]
+	return cards, nil
+}
+
+// 山札にn枚以上あるようにする
+func (t *Table) ensureCards(n int) error {
+	if len(t.deck) < n {
+		switch t.deckExhaustion {
+		case ReshuffleMuck:
+			t.deck = append(t.deck, shuffleDeck(t.muck)...)
+			t.muck = nil
+		case AddSecondDeck:
+			if t.decks < 2 {
+				t.deck = append(t.deck, shuffleDeck(createDeck())...)
+				t.decks++
+			}
+		}
+	}
+	if len(t.deck) < n {
+		return fmt.Errorf("not enough cards in deck: %d needed but %d left", n, len(t.deck))
+	}
+	return nil
+}
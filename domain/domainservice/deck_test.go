@@ -0,0 +1,193 @@
+package domainservice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func newDeckTestPlayers(t *testing.T, n int) []*entity.Player {
+	t.Helper()
+	players := []*entity.Player{}
+	for i := 0; i < n; i++ {
+		players = append(players, newPlayerWithStack(t, fmt.Sprintf("player%d", i), 1000))
+	}
+	return players
+}
+
+func TestTable_DealCards_DeckExhaustion(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    DeckExhaustion
+		wantErr bool
+	}{
+		{
+			name:    "11人に配ると52枚では足りない",
+			rule:    ErrorOnExhaustion,
+			wantErr: true,
+		},
+		{
+			name:    "捨て札がないので、捨て札を戻しても足りない",
+			rule:    ReshuffleMuck,
+			wantErr: true,
+		},
+		{
+			name: "2組目のデッキを使えば配れる",
+			rule: AddSecondDeck,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 公開された方法では11席のテーブルを作れないため、席を直接用意して2組目のデッキを使う扱いで座らせる
+			table := newTable("table", 11)
+			table.deckExhaustion = AddSecondDeck
+			for seat, player := range newDeckTestPlayers(t, 11) {
+				if err := table.Join(seat, player); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// SetDeckExhaustionは11人では52枚を超える扱いしか受け付けないため、直接設定する
+			table.deckExhaustion = tt.rule
+			if err := table.StartHand(); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.PostBlinds(); err != nil {
+				t.Fatal(err)
+			}
+			err := table.DealCards()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Table.DealCards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			// 2組のデッキでは同じカードがあってもミスディールにしない
+			if got := table.Misdeals(); len(got) != 0 {
+				t.Errorf("Table.Misdeals() = %v, want none", got)
+			}
+			for _, player := range table.Players() {
+				if got := len(player.Cards()); got != cardsPerHand {
+					t.Errorf("%s has %v cards, want %v", player.Name(), got, cardsPerHand)
+				}
+			}
+		})
+	}
+}
+
+func TestTable_Draw_DeckExhaustion(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    DeckExhaustion
+		wantErr bool
+	}{
+		{
+			name:    "山札が足りなければエラーにする",
+			rule:    ErrorOnExhaustion,
+			wantErr: true,
+		},
+		{
+			name: "捨て札をシャッフルして山札に加える",
+			rule: ReshuffleMuck,
+		},
+		{
+			name: "2組目のデッキを加える",
+			rule: AddSecondDeck,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 10人に配り、ドロー前のベッティングラウンドを全員コールで終えると山札は残り2枚になる
+			stacks := []int{}
+			for i := 0; i < 10; i++ {
+				stacks = append(stacks, 1000)
+			}
+			table, _ := newAllInTestTable(t, stacks)
+			// ハンド中はSetDeckExhaustionで変えられないため、直接設定する
+			table.deckExhaustion = tt.rule
+			for table.Phase() == PhaseBetting {
+				if err := table.Act(table.Actor(), PassiveDecider{}.DecideAction(table, table.Actor())); err != nil {
+					t.Fatal(err)
+				}
+			}
+			first := table.Drawer()
+			firstDiscards := append([]*valueobject.Card{}, first.Cards()[:2]...)
+			if err := table.Draw(first, firstDiscards); err != nil {
+				t.Fatal(err)
+			}
+			second := table.Drawer()
+			secondDiscards := append([]*valueobject.Card{}, second.Cards()[:2]...)
+			err := table.Draw(second, secondDiscards)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Table.Draw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				// 失敗した場合は手札が元に戻り、同じプレイヤーがもう一度交換できる
+				if table.Drawer() != second || len(second.Cards()) != cardsPerHand {
+					t.Errorf("Table.Drawer() = %v with %v cards, want %v with %v cards", table.Drawer().Name(), len(second.Cards()), second.Name(), cardsPerHand)
+				}
+				for _, card := range secondDiscards {
+					if !containsSameCard(second.Cards(), card) {
+						t.Errorf("discarded card %v is not returned to the hand", card)
+					}
+				}
+				return
+			}
+			if len(second.Cards()) != cardsPerHand {
+				t.Errorf("%s has %v cards, want %v", second.Name(), len(second.Cards()), cardsPerHand)
+			}
+			// 自分が捨てたカードを引き直すことはない
+			for _, card := range secondDiscards {
+				if containsSameCard(second.Cards(), card) {
+					t.Errorf("%s drew back the discarded card %v", second.Name(), card)
+				}
+			}
+			if tt.rule == ReshuffleMuck {
+				for _, card := range firstDiscards {
+					if !containsSameCard(second.Cards(), card) {
+						t.Errorf("%s should draw the reshuffled card %v", second.Name(), card)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTable_Join_DeckCapacity(t *testing.T) {
+	players := newDeckTestPlayers(t, 11)
+	if _, err := NewTable("table", players); err == nil {
+		t.Errorf("NewTable() error = nil with 11 players, want error")
+	}
+	table := newTable("table", 11)
+	for seat, player := range players[:10] {
+		if err := table.Join(seat, player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.Join(10, players[10]); err == nil {
+		t.Errorf("Table.Join() error = nil for the 11th player, want error")
+	}
+	if err := table.SetDeckExhaustion(AddSecondDeck); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Join(10, players[10]); err != nil {
+		t.Errorf("Table.Join() error = %v with a second deck", err)
+	}
+	if err := table.SetDeckExhaustion(ReshuffleMuck); err == nil {
+		t.Errorf("Table.SetDeckExhaustion() error = nil with 11 players, want error")
+	}
+	if got := table.DeckExhaustion(); got != AddSecondDeck {
+		t.Errorf("Table.DeckExhaustion() = %v, want %v", got, AddSecondDeck)
+	}
+}
+
+// 値が同じカードではなく、同じカードそのものを含むかどうか。2組目のデッキには同じスートとランクのカードがある
+func containsSameCard(cards []*valueobject.Card, card *valueobject.Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package domainservice
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// 相手の最終的なハンドのモデル
+type OpponentModel interface {
+	// heroのハンドが相手に勝つ確率を返す。引き分けは半分の勝ちとして数える
+	WinProbability(hero entity.HandValue) float64
+}
+
+// 相手が取りうるハンドを列挙したモデル。各ハンドは等確率とみなす
+type OpponentHands struct {
+	hands []entity.HandValue
+}
+
+func NewOpponentHands(hands [][]*valueobject.Card) (*OpponentHands, error) {
+	o := &OpponentHands{}
+	for _, hand := range hands {
+		value, err := entity.EvaluateHand(hand)
+		if err != nil {
+			return nil, err
+		}
+		o.hands = append(o.hands, value)
+	}
+	return o, nil
+}
+
+func (o *OpponentHands) WinProbability(hero entity.HandValue) float64 {
+	if len(o.hands) == 0 {
+		return 1
+	}
+	wins := 0.0
+	for _, hand := range o.hands {
+		cmp := hero.Compare(hand)
+		if cmp > 0 {
+			wins++
+		} else if cmp == 0 {
+			wins += 0.5
+		}
+	}
+	return wins / float64(len(o.hands))
+}
+
+// 最終的なハンドの価値を返す関数。ビデオポーカーの配当などに使う
+type Payoff func(hand []*valueobject.Card) (float64, error)
+
+// 残すカードと捨てるカードの選択肢とその評価
+type DrawOption struct {
+	Hold    []*valueobject.Card
+	Discard []*valueobject.Card
+	// 交換後の役ごとの確率
+	HandDistribution map[string]float64
+	WinProbability   float64
+	// Payoffが指定されていない場合はWinProbabilityと同じ値になる
+	ExpectedValue float64
+}
+
+type DrawCriterion int
+
+const (
+	ByWinProbability DrawCriterion = iota
+	ByExpectedValue
+)
+
+// ファイブカードドローで残すカードを助言する
+type DiscardAdvisor struct {
+	opponent OpponentModel
+	payoff   Payoff
+	// 交換後の組み合わせがこの数を超える場合は、この数だけ無作為に引いて推定する
+	samples int
+}
+
+func NewDiscardAdvisor(opponent OpponentModel, payoff Payoff, samples int) *DiscardAdvisor {
+	return &DiscardAdvisor{
+		opponent: opponent,
+		payoff:   payoff,
+		samples:  samples,
+	}
+}
+
+const numberOfHandCards = 5
+
+// 32通りの残し方を全て評価し、criterionの高い順に返す
+func (a *DiscardAdvisor) Advise(hand []*valueobject.Card, dead []*valueobject.Card, criterion DrawCriterion) ([]DrawOption, error) {
+	if len(hand) != numberOfHandCards {
+		return nil, fmt.Errorf("number of cards is not %d", numberOfHandCards)
+	}
+	stub := []*valueobject.Card{}
+	for _, card := range valueobject.FullDeck() {
+		if !valueobject.ContainsCard(hand, card) && !valueobject.ContainsCard(dead, card) {
+			stub = append(stub, card)
+		}
+	}
+	options := []DrawOption{}
+	for mask := 0; mask < 1<<numberOfHandCards; mask++ {
+		hold := []*valueobject.Card{}
+		discard := []*valueobject.Card{}
+		for i, card := range hand {
+			if mask&(1<<i) != 0 {
+				hold = append(hold, card)
+			} else {
+				discard = append(discard, card)
+			}
+		}
+		option, err := a.evaluateHold(hold, discard, stub)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		if criterion == ByExpectedValue {
+			return options[i].ExpectedValue > options[j].ExpectedValue
+		}
+		return options[i].WinProbability > options[j].WinProbability
+	})
+	return options, nil
+}
+
+func (a *DiscardAdvisor) evaluateHold(hold []*valueobject.Card, discard []*valueobject.Card, stub []*valueobject.Card) (DrawOption, error) {
+	option := DrawOption{
+		Hold:             hold,
+		Discard:          discard,
+		HandDistribution: map[string]float64{},
+	}
+	draws := len(discard)
+	if draws > len(stub) {
+		return DrawOption{}, fmt.Errorf("not enough cards in stub")
+	}
+	total := 0.0
+	evaluate := func(drawn []*valueobject.Card) error {
+		final := append(append([]*valueobject.Card{}, hold...), drawn...)
+		value, err := entity.EvaluateHand(final)
+		if err != nil {
+			return err
+		}
+		option.HandDistribution[value.Hand()]++
+		if a.opponent != nil {
+			option.WinProbability += a.opponent.WinProbability(value)
+		}
+		if a.payoff != nil {
+			payoff, err := a.payoff(final)
+			if err != nil {
+				return err
+			}
+			option.ExpectedValue += payoff
+		}
+		total++
+		return nil
+	}
+	if countCombinations(len(stub), draws) <= a.samples || a.samples <= 0 {
+		if err := forEachCombination(stub, draws, evaluate); err != nil {
+			return DrawOption{}, err
+		}
+	} else {
+		for i := 0; i < a.samples; i++ {
+			if err := evaluate(pickRandomCards(stub, draws)); err != nil {
+				return DrawOption{}, err
+			}
+		}
+	}
+	for hand := range option.HandDistribution {
+		option.HandDistribution[hand] /= total
+	}
+	option.WinProbability /= total
+	option.ExpectedValue /= total
+	if a.payoff == nil {
+		option.ExpectedValue = option.WinProbability
+	}
+	return option, nil
+}
+
+// n枚からk枚を選ぶ組み合わせの数を返す
+func countCombinations(n int, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 0; i < k; i++ {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
+
+// cardsからk枚を選ぶ全ての組み合わせについてfを呼ぶ
+func forEachCombination(cards []*valueobject.Card, k int, f func([]*valueobject.Card) error) error {
+	selected := make([]*valueobject.Card, k)
+	var choose func(start int, depth int) error
+	choose = func(start int, depth int) error {
+		if depth == k {
+			return f(selected)
+		}
+		for i := start; i <= len(cards)-(k-depth); i++ {
+			selected[depth] = cards[i]
+			if err := choose(i+1, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return choose(0, 0)
+}
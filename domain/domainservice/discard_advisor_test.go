@@ -0,0 +1,145 @@
+package domainservice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func findDrawOption(options []DrawOption, hold []*valueobject.Card) *DrawOption {
+	for i, option := range options {
+		if len(option.Hold) != len(hold) {
+			continue
+		}
+		matched := true
+		for _, card := range hold {
+			if !valueobject.ContainsCard(option.Hold, card) {
+				matched = false
+			}
+		}
+		if matched {
+			return &options[i]
+		}
+	}
+	return nil
+}
+
+func TestDiscardAdvisor_Advise(t *testing.T) {
+	tests := []struct {
+		name         string
+		hand         string
+		dead         string
+		hold         string
+		wantHand     string
+		wantProbable float64
+	}{
+		{
+			name:         "フラッシュドロー",
+			hand:         "As7s9sKs2h",
+			dead:         "",
+			hold:         "As7s9sKs",
+			wantHand:     "フラッシュ",
+			wantProbable: 9.0 / 47.0,
+		},
+		{
+			name:         "デッドカードを考慮したフラッシュドロー",
+			hand:         "As7s9sKs2h",
+			dead:         "3s4s",
+			hold:         "As7s9sKs",
+			wantHand:     "フラッシュ",
+			wantProbable: 7.0 / 45.0,
+		},
+		{
+			name:         "全て残す",
+			hand:         "As7s9sKs2h",
+			dead:         "",
+			hold:         "As7s9sKs2h",
+			wantHand:     "ハイカード",
+			wantProbable: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advisor := NewDiscardAdvisor(nil, nil, 100)
+			options, err := advisor.Advise(mustParseCards(t, tt.hand), mustParseCards(t, tt.dead), ByWinProbability)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(options) != 32 {
+				t.Fatalf("len(options) = %v, want 32", len(options))
+			}
+			option := findDrawOption(options, mustParseCards(t, tt.hold))
+			if option == nil {
+				t.Fatalf("option for %s not found", tt.hold)
+			}
+			if got := option.HandDistribution[tt.wantHand]; math.Abs(got-tt.wantProbable) > 1e-9 {
+				t.Errorf("HandDistribution[%s] = %v, want %v", tt.wantHand, got, tt.wantProbable)
+			}
+		})
+	}
+}
+
+func TestDiscardAdvisor_Advise_Ranking(t *testing.T) {
+	opponent, err := NewOpponentHands([][]*valueobject.Card{
+		mustParseCards(t, "KsKhKd2c2d"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	advisor := NewDiscardAdvisor(opponent, nil, 50)
+	options, err := advisor.Advise(mustParseCards(t, "AsAhAdAc3h"), nil, ByWinProbability)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options[0].WinProbability != 1 {
+		t.Errorf("options[0].WinProbability = %v, want 1", options[0].WinProbability)
+	}
+	if len(options[0].Hold) < 4 {
+		t.Errorf("options[0].Hold = %v, want to hold four aces", options[0].Hold)
+	}
+	if options[len(options)-1].WinProbability >= 1 {
+		t.Errorf("worst option should not always win")
+	}
+}
+
+func TestDiscardAdvisor_Advise_Payoff(t *testing.T) {
+	// ペア以上で1を得る配当
+	payoff := func(hand []*valueobject.Card) (float64, error) {
+		value, err := evaluateForTest(hand)
+		if err != nil {
+			return 0, err
+		}
+		if value == "ハイカード" {
+			return 0, nil
+		}
+		return 1, nil
+	}
+	advisor := NewDiscardAdvisor(nil, payoff, 50)
+	options, err := advisor.Advise(mustParseCards(t, "9s9h2d5c7h"), nil, ByExpectedValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options[0].ExpectedValue != 1 {
+		t.Errorf("options[0].ExpectedValue = %v, want 1", options[0].ExpectedValue)
+	}
+	if !valueobject.ContainsCard(options[0].Hold, valueobject.NewCard("spade", "9")) {
+		t.Errorf("options[0].Hold = %v, want to hold the pair", options[0].Hold)
+	}
+}
+
+func TestDiscardAdvisor_Advise_InvalidHand(t *testing.T) {
+	advisor := NewDiscardAdvisor(nil, nil, 50)
+	if _, err := advisor.Advise(mustParseCards(t, "9s9h2d5c"), nil, ByWinProbability); err == nil {
+		t.Errorf("Advise() error = nil, want error")
+	}
+}
+
+func evaluateForTest(hand []*valueobject.Card) (string, error) {
+	value, err := entity.EvaluateHand(hand)
+	if err != nil {
+		return "", err
+	}
+	return value.Hand(), nil
+}
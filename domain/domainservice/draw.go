@@ -0,0 +1,70 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// 現在カードを交換するプレイヤー。ドロー中でなければnil
+func (t *Table) Drawer() *entity.Player {
+	if t.phase != PhaseDraw || t.drawer < 0 {
+		return nil
+	}
+	return t.players[t.drawer]
+}
+
+func (t *Table) startDraw() {
+	t.phase = PhaseDraw
+	t.drawer = t.nextDrawer(0)
+	if t.drawer < 0 {
+		t.startBettingRound(2)
+	}
+}
+
+// fromから順に、フォールドしていないプレイヤーのインデックスを返す
+func (t *Table) nextDrawer(from int) int {
+	for i := from; i < len(t.players); i++ {
+		if !t.folded[t.players[i]] {
+			return i
+		}
+	}
+	return -1
+}
+
+// discardsを捨てて同じ枚数を山札から引く。discardsが空ならスタンドパット
+func (t *Table) Draw(player *entity.Player, discards []*valueobject.Card) error {
+	if err := t.requirePhase("draw", PhaseDraw); err != nil {
+		return err
+	}
+	if t.Drawer() != player {
+		return fmt.Errorf("it is not %s's turn to draw", player.Name())
+	}
+	if t.runs > 1 {
+		if err := t.drawRuns(player, discards); err != nil {
+			return err
+		}
+	} else {
+		if err := player.Discard(discards); err != nil {
+			return err
+		}
+		// 捨てたカードは、引き終えてから捨て札に加える。捨て札を山札に戻す場合でも、自分が捨てたカードを引き直すことはない
+		cards, err := t.takeCards(len(discards))
+		if err != nil {
+			for _, card := range discards {
+				player.DrawCard(card)
+			}
+			return err
+		}
+		for _, card := range cards {
+			player.DrawCard(card)
+		}
+		t.muck = append(t.muck, discards...)
+	}
+	t.drawer = t.nextDrawer(t.drawer + 1)
+	if t.drawer < 0 {
+		t.startBettingRound(2)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package domainservice
+
+import (
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func TestTable_Draw(t *testing.T) {
+	table, players := newBettingTestTable(t)
+	for _, seat := range []int{0, 1} {
+		if err := table.Act(players[seat], Action{Type: ActionCall}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.Act(players[2], Action{Type: ActionCheck}); err != nil {
+		t.Fatal(err)
+	}
+	// ドローはボタンの次のプレイヤーから行う
+	drawer := table.Drawer()
+	if drawer != players[1] {
+		t.Fatalf("Table.Drawer() = %v, want players[1]", drawer)
+	}
+	if err := table.Draw(players[0], nil); err == nil {
+		t.Errorf("Table.Draw() out of turn error = nil, want error")
+	}
+	discards := append([]*valueobject.Card{}, drawer.Cards()[:2]...)
+	kept := append([]*valueobject.Card{}, drawer.Cards()[2:]...)
+	if err := table.Draw(drawer, discards); err != nil {
+		t.Fatal(err)
+	}
+	if len(drawer.Cards()) != numberOfHandCards {
+		t.Errorf("len(Cards()) = %v, want %v", len(drawer.Cards()), numberOfHandCards)
+	}
+	for _, card := range discards {
+		if valueobject.ContainsCard(drawer.Cards(), card) {
+			t.Errorf("discarded card %v is still in hand", card)
+		}
+	}
+	for _, card := range kept {
+		if !valueobject.ContainsCard(drawer.Cards(), card) {
+			t.Errorf("kept card %v is not in hand", card)
+		}
+	}
+	for table.Phase() == PhaseDraw {
+		if err := table.Draw(table.Drawer(), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if table.Phase() != PhaseBetting || table.BettingRound() != 2 {
+		t.Errorf("Table.Phase() = %v round %v, want %v round 2", table.Phase(), table.BettingRound(), PhaseBetting)
+	}
+}
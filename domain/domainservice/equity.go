@@ -0,0 +1,166 @@
+package domainservice
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+const boardSize = 5
+
+// ハンド同士を比較した勝率。Equityは引き分けを半分の勝ちとして数える
+type EquityResult struct {
+	Win  float64
+	Tie  float64
+	Lose float64
+}
+
+func (e EquityResult) Equity() float64 {
+	return e.Win + e.Tie/2
+}
+
+// ハンドとレンジのエクイティを計算する
+// ボードが5枚そろっている場合は全組み合わせを数え上げ、そうでない場合はiterations回のモンテカルロ法で推定する
+func HandVsRangeEquity(hand []*valueobject.Card, villain *valueobject.HandRange, board []*valueobject.Card, iterations int) (EquityResult, error) {
+	if len(hand) != 2 {
+		return EquityResult{}, fmt.Errorf("hand must have 2 cards")
+	}
+	hero := valueobject.NewHandRange([]valueobject.WeightedCombo{{Combo: valueobject.NewCombo(hand[0], hand[1]), Weight: 1}})
+	return RangeVsRangeEquity(hero, villain, board, iterations)
+}
+
+// レンジ同士のエクイティを計算する
+func RangeVsRangeEquity(hero *valueobject.HandRange, villain *valueobject.HandRange, board []*valueobject.Card, iterations int) (EquityResult, error) {
+	if len(board) > boardSize {
+		return EquityResult{}, fmt.Errorf("board has more than %d cards", boardSize)
+	}
+	hero = hero.RemoveBlocked(board)
+	villain = villain.RemoveBlocked(board)
+	pairs := comboPairs(hero, villain)
+	if len(pairs) == 0 {
+		return EquityResult{}, fmt.Errorf("no valid combos")
+	}
+	if len(board) == boardSize {
+		return enumerateEquity(pairs, board)
+	}
+	if iterations <= 0 {
+		return EquityResult{}, fmt.Errorf("iterations must be positive")
+	}
+	return simulateEquity(pairs, board, iterations)
+}
+
+type comboPair struct {
+	hero    valueobject.Combo
+	villain valueobject.Combo
+	weight  float64
+}
+
+// カードが重ならないコンボの組を列挙する
+func comboPairs(hero *valueobject.HandRange, villain *valueobject.HandRange) []comboPair {
+	pairs := []comboPair{}
+	for _, h := range hero.Combos() {
+		for _, v := range villain.Combos() {
+			if h.Combo.Overlaps(v.Combo) {
+				continue
+			}
+			pairs = append(pairs, comboPair{hero: h.Combo, villain: v.Combo, weight: h.Weight * v.Weight})
+		}
+	}
+	return pairs
+}
+
+func enumerateEquity(pairs []comboPair, board []*valueobject.Card) (EquityResult, error) {
+	result := EquityResult{}
+	total := 0.0
+	for _, pair := range pairs {
+		cmp, err := compareShowdown(pair.hero, pair.villain, board)
+		if err != nil {
+			return EquityResult{}, err
+		}
+		result.add(cmp, pair.weight)
+		total += pair.weight
+	}
+	return result.normalize(total), nil
+}
+
+func simulateEquity(pairs []comboPair, board []*valueobject.Card, iterations int) (EquityResult, error) {
+	totalWeight := 0.0
+	for _, pair := range pairs {
+		totalWeight += pair.weight
+	}
+	result := EquityResult{}
+	for i := 0; i < iterations; i++ {
+		pair := pickComboPair(pairs, totalWeight)
+		dead := append(append(append([]*valueobject.Card{}, board...), pair.hero.Cards()...), pair.villain.Cards()...)
+		runout := append([]*valueobject.Card{}, board...)
+		runout = append(runout, drawRandomCards(dead, boardSize-len(board))...)
+		cmp, err := compareShowdown(pair.hero, pair.villain, runout)
+		if err != nil {
+			return EquityResult{}, err
+		}
+		result.add(cmp, 1)
+	}
+	return result.normalize(float64(iterations)), nil
+}
+
+// 重みに比例した確率でコンボの組を選ぶ
+func pickComboPair(pairs []comboPair, totalWeight float64) comboPair {
+	r := rand.Float64() * totalWeight
+	for _, pair := range pairs {
+		r -= pair.weight
+		if r < 0 {
+			return pair
+		}
+	}
+	return pairs[len(pairs)-1]
+}
+
+// deadに含まれないカードからn枚を無作為に選ぶ
+func drawRandomCards(dead []*valueobject.Card, n int) []*valueobject.Card {
+	live := []*valueobject.Card{}
+	for _, card := range valueobject.FullDeck() {
+		if !valueobject.ContainsCard(dead, card) {
+			live = append(live, card)
+		}
+	}
+	return pickRandomCards(live, n)
+}
+
+// cardsからn枚を無作為に選ぶ。cardsは変更しない
+func pickRandomCards(cards []*valueobject.Card, n int) []*valueobject.Card {
+	picked := append([]*valueobject.Card{}, cards...)
+	for i := 0; i < n; i++ {
+		j := i + rand.Intn(len(picked)-i)
+		picked[i], picked[j] = picked[j], picked[i]
+	}
+	return picked[:n]
+}
+
+func compareShowdown(hero valueobject.Combo, villain valueobject.Combo, board []*valueobject.Card) (int, error) {
+	heroValue, err := entity.EvaluateBestHand(append(hero.Cards(), board...))
+	if err != nil {
+		return 0, err
+	}
+	villainValue, err := entity.EvaluateBestHand(append(villain.Cards(), board...))
+	if err != nil {
+		return 0, err
+	}
+	return heroValue.Compare(villainValue), nil
+}
+
+func (e *EquityResult) add(cmp int, weight float64) {
+	switch {
+	case cmp > 0:
+		e.Win += weight
+	case cmp < 0:
+		e.Lose += weight
+	default:
+		e.Tie += weight
+	}
+}
+
+func (e EquityResult) normalize(total float64) EquityResult {
+	return EquityResult{Win: e.Win / total, Tie: e.Tie / total, Lose: e.Lose / total}
+}
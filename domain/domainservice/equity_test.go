@@ -0,0 +1,122 @@
+package domainservice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func TestHandVsRangeEquity(t *testing.T) {
+	tests := []struct {
+		name       string
+		hand       string
+		villain    string
+		board      string
+		iterations int
+		want       float64
+		tolerance  float64
+		wantErr    bool
+	}{
+		{
+			name:       "ボードが確定していて勝つ",
+			hand:       "AsAh",
+			villain:    "KK",
+			board:      "2c7d9hJs3c",
+			iterations: 0,
+			want:       1,
+			tolerance:  0,
+			wantErr:    false,
+		},
+		{
+			name:       "ボードが確定していて一部のコンボに負ける",
+			hand:       "AsAh",
+			villain:    "KK, 22",
+			board:      "2c7d9hJs3c",
+			iterations: 0,
+			// 22は2cがボードにあるため3コンボ、KKは6コンボ
+			want:      6.0 / 9.0,
+			tolerance: 1e-9,
+			wantErr:   false,
+		},
+		{
+			name:       "ボードが確定していて引き分け",
+			hand:       "2s3h",
+			villain:    "2d3c",
+			board:      "AcKdQhJsTc",
+			iterations: 0,
+			want:       0.5,
+			tolerance:  0,
+			wantErr:    false,
+		},
+		{
+			name:       "ボードの途中からシミュレーションする",
+			hand:       "AsAh",
+			villain:    "KsKh",
+			board:      "2c7d9h",
+			iterations: 2000,
+			want:       0.91,
+			tolerance:  0.05,
+			wantErr:    false,
+		},
+		{
+			name:       "全てのコンボがブロックされている",
+			hand:       "AsAh",
+			villain:    "AsKs",
+			board:      "",
+			iterations: 100,
+			want:       0,
+			tolerance:  0,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand, err := valueobject.ParseCards(tt.hand)
+			if err != nil {
+				t.Fatal(err)
+			}
+			villain, err := valueobject.ParseHandRange(tt.villain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			board, err := valueobject.ParseCards(tt.board)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := HandVsRangeEquity(hand, villain, board, tt.iterations)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HandVsRangeEquity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if math.Abs(got.Equity()-tt.want) > tt.tolerance {
+				t.Errorf("HandVsRangeEquity().Equity() = %v, want %v", got.Equity(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeVsRangeEquity(t *testing.T) {
+	hero, err := valueobject.ParseHandRange("AA, KK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	villain, err := valueobject.ParseHandRange("QQ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board, err := valueobject.ParseCards("2c7d9hJs3c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := RangeVsRangeEquity(hero, villain, board, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Equity() != 1 {
+		t.Errorf("RangeVsRangeEquity().Equity() = %v, want 1", got.Equity())
+	}
+}
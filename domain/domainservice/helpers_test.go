@@ -0,0 +1,114 @@
+package domainservice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// moneyだけ所持金を持ったプレイヤーを作る
+func newTestPlayer(t *testing.T, name string, money int) *entity.Player {
+	t.Helper()
+	player, err := entity.NewPlayer(name, money)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return player
+}
+
+// stackだけチップを持ってテーブルに着いたプレイヤーを作る
+func newPlayerWithStack(t *testing.T, name string, stack int) *entity.Player {
+	t.Helper()
+	player := newTestPlayer(t, name, stack)
+	if err := player.BuyIn(stack); err != nil {
+		t.Fatal(err)
+	}
+	return player
+}
+
+func mustParseCards(t *testing.T, notation string) []*valueobject.Card {
+	t.Helper()
+	cards, err := valueobject.ParseCards(notation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+// 席0から順にstacksのスタックを持つプレイヤーをblindsのテーブルに座らせ、ハウスルールを追加してハンドを始め、ブラインドを支払うまで進める
+func newPostedTestTable(t *testing.T, blinds Blinds, stacks []int, rules ...HouseRule) (*Table, []*entity.Player) {
+	t.Helper()
+	table, err := NewTableWithSeats("table", len(stacks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBlinds(blinds); err != nil {
+		t.Fatal(err)
+	}
+	players := []*entity.Player{}
+	for seat, stack := range stacks {
+		player := newPlayerWithStack(t, fmt.Sprintf("player%d", seat), stack)
+		if err := table.Join(seat, player); err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, player)
+	}
+	for _, rule := range rules {
+		if err := table.AddHouseRule(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	return table, players
+}
+
+// 席0から順にstacksのスタックを持つプレイヤーを座らせ、カードを配ってドロー前のベッティングラウンドを始める
+// ボタンは席0、スモールブラインドは席1、ビッグブラインドは席2になる
+func newAllInTestTable(t *testing.T, stacks []int) (*Table, []*entity.Player) {
+	t.Helper()
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, stacks)
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	return table, players
+}
+
+// tableのハンドを、全員がオールインかコールをして交換せずにphaseまで進める
+func playAllInUntil(t *testing.T, table *Table, phase Phase) {
+	t.Helper()
+	for table.Phase() != phase {
+		switch table.Phase() {
+		case PhaseBetting:
+			if err := table.Act(table.Actor(), allInDecider{}.DecideAction(table, table.Actor())); err != nil {
+				t.Fatal(err)
+			}
+		case PhaseDraw:
+			if err := table.Draw(table.Drawer(), nil); err != nil {
+				t.Fatal(err)
+			}
+		default:
+			t.Fatalf("Table.Phase() = %v, want %v", table.Phase(), phase)
+		}
+	}
+}
+
+// オールインできればオールインし、できなければコールする。カードは交換しない
+type allInDecider struct{}
+
+func (allInDecider) DecideAction(t *Table, player *entity.Player) Action {
+	if containsAction(t.PermittedActions(player), ActionAllIn) {
+		return Action{Type: ActionAllIn}
+	}
+	return Action{Type: ActionCall}
+}
+
+func (allInDecider) DecideDiscards(t *Table, player *entity.Player) []*valueobject.Card {
+	return nil
+}
@@ -0,0 +1,193 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// テーブルに追加できるハウスルール。必要な段階のフック (PostingRule、DealingRule、PayoutRule) を実装する
+// フックはテーブルの公開された操作を使ってハンドを変える
+type HouseRule interface {
+	// テーブル上でルールを区別する名前
+	Name() string
+}
+
+// アンティとブラインドを支払う段階に関わるハウスルール
+type PostingRule interface {
+	HouseRule
+	// アンティとブラインドを支払う前に呼ばれる。trueを返すと通常のアンティとブラインドを支払わない
+	BeforePosting(t *Table) (bool, error)
+	// アンティとブラインドを支払った後に呼ばれる
+	AfterPosting(t *Table) error
+}
+
+// カードを配る段階に関わるハウスルール
+type DealingRule interface {
+	HouseRule
+	// カードを配った後に呼ばれる。falseを返すとドロー前のベッティングラウンドを行わずにドローへ進む
+	AfterDealing(t *Table) (bool, error)
+}
+
+// 支払いの段階に関わるハウスルール
+type PayoutRule interface {
+	HouseRule
+	// ポットを配った後に呼ばれる。winnersはポットを獲得したプレイヤー
+	AfterPayout(t *Table, winners []*entity.Player) error
+}
+
+// 追加した順のハウスルール
+func (t *Table) HouseRules() []HouseRule {
+	return append([]HouseRule{}, t.houseRules...)
+}
+
+// ハウスルールを追加する。ハンド中はできない
+func (t *Table) AddHouseRule(rule HouseRule) error {
+	if t.IsHandInProgress() {
+		return fmt.Errorf("cannot change house rules during a hand")
+	}
+	for _, existing := range t.houseRules {
+		if existing.Name() == rule.Name() {
+			return fmt.Errorf("house rule %s is already added", rule.Name())
+		}
+	}
+	t.houseRules = append(t.houseRules, rule)
+	return nil
+}
+
+// ハウスルールを取り除く。ハンド中はできない
+func (t *Table) RemoveHouseRule(name string) error {
+	if t.IsHandInProgress() {
+		return fmt.Errorf("cannot change house rules during a hand")
+	}
+	for i, rule := range t.houseRules {
+		if rule.Name() == name {
+			t.houseRules = append(t.houseRules[:i], t.houseRules[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("house rule %s is not added", name)
+}
+
+// 通常のアンティとブラインドの前に呼ぶフック。いずれかのルールが代わりに支払わせた場合はtrueを返す
+func (t *Table) beforePosting() (bool, error) {
+	replaced := false
+	for _, rule := range t.houseRules {
+		if rule, ok := rule.(PostingRule); ok {
+			skip, err := rule.BeforePosting(t)
+			if err != nil {
+				return false, err
+			}
+			replaced = replaced || skip
+		}
+	}
+	return replaced, nil
+}
+
+func (t *Table) afterPosting() error {
+	for _, rule := range t.houseRules {
+		if rule, ok := rule.(PostingRule); ok {
+			if err := rule.AfterPosting(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// カードを配った後に呼ぶフック。ドロー前のベッティングラウンドを行うかどうかを返す
+func (t *Table) afterDealing() (bool, error) {
+	betting := true
+	for _, rule := range t.houseRules {
+		if rule, ok := rule.(DealingRule); ok {
+			ok, err := rule.AfterDealing(t)
+			if err != nil {
+				return false, err
+			}
+			betting = betting && ok
+		}
+	}
+	return betting, nil
+}
+
+func (t *Table) afterPayout(winners []*entity.Player) error {
+	for _, rule := range t.houseRules {
+		if rule, ok := rule.(PayoutRule); ok {
+			if err := rule.AfterPayout(t, winners); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ハウスルールによる強制ベットとして、アンティをポットに入れる。スタックが足りない場合はオールインになる
+func (t *Table) PostAnte(player *entity.Player, amount int) error {
+	if err := t.requirePhase("post ante", PhasePosting); err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return fmt.Errorf("ante must be positive")
+	}
+	if !containsPlayer(t.players, player) {
+		return fmt.Errorf("player is not in the hand")
+	}
+	t.postAnte(player, amount)
+	return nil
+}
+
+// ブラインドの後に、ビッグブラインド以外のプレイヤーが任意で掛け金を置く (ストラドル)
+// ストラドルはビッグブラインドの2倍以上で、ドロー前はストラドルしたプレイヤーの次からアクションし、ストラドルしたプレイヤーが最後にアクションする
+func (t *Table) PostStraddle(player *entity.Player, amount int) error {
+	if err := t.requirePhase("post straddle", PhasePosting); err != nil {
+		return err
+	}
+	if t.straddler != nil {
+		return fmt.Errorf("straddle is already posted")
+	}
+	if t.CurrentBet() == 0 {
+		return fmt.Errorf("cannot straddle without blinds")
+	}
+	if !containsPlayer(t.players, player) {
+		return fmt.Errorf("player is not in the hand")
+	}
+	if player == t.livePlayerAt(t.smallBlindSeat) || player == t.livePlayerAt(t.bigBlindSeat) {
+		return fmt.Errorf("blinds cannot straddle")
+	}
+	if amount < 2*t.blinds.BigBlind {
+		return fmt.Errorf("straddle must be at least %d", 2*t.blinds.BigBlind)
+	}
+	if player.Stack() < amount {
+		return fmt.Errorf("not enough chips")
+	}
+	t.record(LedgerBlind, player, player.PostBlind(amount))
+	t.straddler = player
+	return nil
+}
+
+// このハンドでストラドルしたプレイヤー。いなければnil
+func (t *Table) Straddler() *entity.Player {
+	return t.straddler
+}
+
+// ハンドが終わった後に、ハウスルールによる支払いとしてfromのスタックからtoへ移す。スタックが足りなければ全額を移し、移した額を返す
+func (t *Table) PaySidePayment(from, to *entity.Player, amount int) (int, error) {
+	if err := t.requirePhase("pay side payment", PhaseComplete); err != nil {
+		return 0, err
+	}
+	if amount <= 0 {
+		return 0, fmt.Errorf("side payment must be positive")
+	}
+	if from == to {
+		return 0, fmt.Errorf("player cannot pay themselves")
+	}
+	if _, err := t.SeatOf(from); err != nil {
+		return 0, err
+	}
+	if _, err := t.SeatOf(to); err != nil {
+		return 0, err
+	}
+	paid := t.record(LedgerSidePayment, from, from.PostAnte(amount))
+	to.Win(t.record(LedgerSidePaymentReceived, to, paid))
+	return paid, nil
+}
@@ -0,0 +1,188 @@
+package domainservice
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// 呼ばれたフックとそのときのフェーズを記録するハウスルール
+type recordingRule struct {
+	name  string
+	calls *[]string
+}
+
+func (r recordingRule) Name() string {
+	return r.name
+}
+
+func (r recordingRule) BeforePosting(t *Table) (bool, error) {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s before posting %d", r.name, t.CurrentBet()))
+	return false, nil
+}
+
+func (r recordingRule) AfterPosting(t *Table) error {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s after posting %d", r.name, t.CurrentBet()))
+	return nil
+}
+
+func (r recordingRule) AfterDealing(t *Table) (bool, error) {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s after dealing %d", r.name, len(t.Players()[0].Cards())))
+	return true, nil
+}
+
+func (r recordingRule) AfterPayout(t *Table, winners []*entity.Player) error {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s after payout %s", r.name, t.Phase()))
+	return nil
+}
+
+func TestTable_HouseRuleHooks(t *testing.T) {
+	calls := []string{}
+	first, second := recordingRule{name: "first", calls: &calls}, recordingRule{name: "second", calls: &calls}
+	table, _ := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100}, first, second)
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	for table.Phase() == PhaseBetting {
+		if err := table.Act(table.Actor(), Action{Type: ActionFold}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.DistributeChips(table.RemainingPlayers()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"first before posting 0",
+		"second before posting 0",
+		"first after posting 10",
+		"second after posting 10",
+		"first after dealing 5",
+		"second after dealing 5",
+		"first after payout " + PhaseComplete.String(),
+		"second after payout " + PhaseComplete.String(),
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestTable_AddHouseRule(t *testing.T) {
+	table, err := NewTableWithSeats("table", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.AddHouseRule(Straddle{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.AddHouseRule(Straddle{Position: MississippiStraddle}); err == nil {
+		t.Errorf("Table.AddHouseRule() error = nil for a duplicate rule, want error")
+	}
+	bounty, err := NewSevenDeuceBounty(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.AddHouseRule(bounty); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := table.HouseRules(), []HouseRule{Straddle{}, bounty}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.HouseRules() = %v, want %v", got, want)
+	}
+	if err := table.RemoveHouseRule("straddle"); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.RemoveHouseRule("straddle"); err == nil {
+		t.Errorf("Table.RemoveHouseRule() error = nil for a removed rule, want error")
+	}
+	if got, want := table.HouseRules(), []HouseRule{bounty}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.HouseRules() = %v, want %v", got, want)
+	}
+
+	table, _ = newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100})
+	if err := table.AddHouseRule(Straddle{}); err == nil {
+		t.Errorf("Table.AddHouseRule() error = nil during a hand, want error")
+	}
+	if err := table.RemoveHouseRule("straddle"); err == nil {
+		t.Errorf("Table.RemoveHouseRule() error = nil during a hand, want error")
+	}
+}
+
+func TestTable_PaySidePayment(t *testing.T) {
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{3, 100, 100})
+	if _, err := table.PaySidePayment(players[0], players[1], 10); err == nil {
+		t.Errorf("Table.PaySidePayment() error = nil during a hand, want error")
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	for table.Phase() == PhaseBetting {
+		if err := table.Act(table.Actor(), Action{Type: ActionFold}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.DistributeChips(table.RemainingPlayers()); err != nil {
+		t.Fatal(err)
+	}
+	stacks := []int{players[0].Stack(), players[1].Stack(), players[2].Stack()}
+	tests := []struct {
+		name     string
+		from, to *entity.Player
+		amount   int
+		want     int
+		wantErr  bool
+	}{
+		{
+			name:   "スタックから支払う",
+			from:   players[1],
+			to:     players[2],
+			amount: 10,
+			want:   10,
+		},
+		{
+			name:   "スタックが足りなければ全額を支払う",
+			from:   players[0],
+			to:     players[2],
+			amount: 10,
+			want:   stacks[0],
+		},
+		{
+			name:    "自分には支払えない",
+			from:    players[0],
+			to:      players[0],
+			amount:  10,
+			wantErr: true,
+		},
+		{
+			name:    "0は支払えない",
+			from:    players[0],
+			to:      players[1],
+			amount:  0,
+			wantErr: true,
+		},
+		{
+			name:    "席にいないプレイヤーには支払えない",
+			from:    players[0],
+			to:      newTestPlayer(t, "stranger", 100),
+			amount:  10,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.PaySidePayment(tt.from, tt.to, tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Table.PaySidePayment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Table.PaySidePayment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got, want := []int{players[0].Stack(), players[1].Stack(), players[2].Stack()}, []int{0, stacks[1] - 10, stacks[2] + 10 + stacks[0]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stacks = %v, want %v", got, want)
+	}
+	if err := table.EndHand(); err != nil {
+		t.Errorf("Table.EndHand() error = %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+type LedgerEntryType int
+
+const (
+	// プレイヤーがチップを持ってテーブルに着く
+	LedgerBuyIn LedgerEntryType = iota
+	LedgerAnte
+	LedgerBlind
+	LedgerBet
+	// 誰にもコールされなかった掛け金をプレイヤーに返す
+	LedgerRefund
+	// ポットを勝者に配る
+	LedgerAward
+	// ハウスがポットから手数料を取る
+	LedgerRake
+	// ハウスがプレイヤーからタイムチャージを取る
+	LedgerTimeCollection
+	// プレイヤーがチップを持ってテーブルを離れる
+	LedgerCashOut
+	// ハウスルールにより、ポットとは別にプレイヤーが他のプレイヤーへ支払う
+	LedgerSidePayment
+	// ハウスルールにより、ポットとは別にプレイヤーが他のプレイヤーから受け取る
+	LedgerSidePaymentReceived
+)
+
+func (l LedgerEntryType) String() string {
+	switch l {
+	case LedgerBuyIn:
+		return "buy-in"
+	case LedgerAnte:
+		return "ante"
+	case LedgerBlind:
+		return "blind"
+	case LedgerBet:
+		return "bet"
+	case LedgerRefund:
+		return "refund"
+	case LedgerAward:
+		return "award"
+	case LedgerRake:
+		return "rake"
+	case LedgerTimeCollection:
+		return "time collection"
+	case LedgerCashOut:
+		return "cash-out"
+	case LedgerSidePayment:
+		return "side payment"
+	case LedgerSidePaymentReceived:
+		return "side payment received"
+	default:
+		return "unknown"
+	}
+}
+
+// チップの移動の記録
+type LedgerEntry struct {
+	// 記録した順に1から振られる番号
+	Sequence int
+	// 移動が起きたハンドの番号。最初のハンドが始まる前は0
+	HandNumber int
+	Type       LedgerEntryType
+	// レーキの場合はnil
+	Player *entity.Player
+	Amount int
+}
+
+// 追記のみ可能なチップの移動の台帳
+type Ledger struct {
+	entries []LedgerEntry
+}
+
+// 記録されたすべての移動。呼び出し側が変更しても台帳には影響しない
+func (l *Ledger) Entries() []LedgerEntry {
+	return append([]LedgerEntry{}, l.entries...)
+}
+
+// handNumberのハンドで記録された移動
+func (l *Ledger) EntriesForHand(handNumber int) []LedgerEntry {
+	entries := []LedgerEntry{}
+	for _, entry := range l.entries {
+		if entry.HandNumber == handNumber {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (l *Ledger) add(handNumber int, entryType LedgerEntryType, player *entity.Player, amount int) {
+	l.entries = append(l.entries, LedgerEntry{
+		Sequence:   len(l.entries) + 1,
+		HandNumber: handNumber,
+		Type:       entryType,
+		Player:     player,
+		Amount:     amount,
+	})
+}
+
+// テーブルにあるはずのチップの額。バイインからキャッシュアウトとレーキを引いた額
+func (l *Ledger) Balance() int {
+	balance := 0
+	for _, entry := range l.entries {
+		switch entry.Type {
+		case LedgerBuyIn:
+			balance += entry.Amount
+		case LedgerCashOut, LedgerRake, LedgerTimeCollection:
+			balance -= entry.Amount
+		}
+	}
+	return balance
+}
+
+// handNumberのハンドでポットに入った額と、ポットから出た額
+func (l *Ledger) handFlows(handNumber int) (in, out int) {
+	for _, entry := range l.EntriesForHand(handNumber) {
+		switch entry.Type {
+		case LedgerAnte, LedgerBlind, LedgerBet:
+			in += entry.Amount
+		case LedgerRefund, LedgerAward, LedgerRake:
+			out += entry.Amount
+		}
+	}
+	return in, out
+}
+
+func (t *Table) Ledger() *Ledger {
+	return t.ledger
+}
+
+// テーブルで行ったハンドの数。最初のハンドは1
+func (t *Table) HandNumber() int {
+	return t.handNumber
+}
+
+// チップの移動を台帳に記録し、その額を返す。0の移動は記録しない
+func (t *Table) record(entryType LedgerEntryType, player *entity.Player, amount int) int {
+	if amount == 0 {
+		return 0
+	}
+	t.ledger.add(t.handNumber, entryType, player, amount)
+	switch entryType {
+	case LedgerAnte, LedgerBlind, LedgerBet:
+		t.contributions[player] += amount
+	case LedgerRefund:
+		t.contributions[player] -= amount
+	}
+	return amount
+}
+
+// 席にいるプレイヤーのスタックと掛け金、ポットの合計
+func (t *Table) ChipsInPlay() int {
+	chips := t.pot
+	for _, seat := range t.seats {
+		if !seat.IsEmpty() {
+			chips += seat.player.Stack() + seat.player.Chips()
+		}
+	}
+	return chips
+}
+
+// チップが生まれたり消えたりしていないことを確かめる
+// テーブル上のチップが台帳の残高と一致し、終わったハンドではポットに入った額がすべて払い出されている必要がある
+func (t *Table) CheckConservation() error {
+	if balance, chips := t.ledger.Balance(), t.ChipsInPlay(); balance != chips {
+		return fmt.Errorf("chips are not conserved: ledger balance is %d but %d chips are in play", balance, chips)
+	}
+	if t.phase == PhaseComplete || t.phase == PhaseWaiting {
+		if in, out := t.ledger.handFlows(t.handNumber); in != out {
+			return fmt.Errorf("hand %d is not balanced: %d chips went in but %d came out", t.handNumber, in, out)
+		}
+	}
+	return nil
+}
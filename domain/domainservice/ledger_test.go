@@ -0,0 +1,68 @@
+package domainservice
+
+import (
+	"testing"
+)
+
+func TestTable_Ledger(t *testing.T) {
+	table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if got := table.Ledger().Balance(); got != 300 {
+		t.Errorf("Ledger.Balance() after buy-ins = %v, want 300", got)
+	}
+	session := NewSession(table)
+	session.AddStopCondition(MaxHands(5))
+	if _, err := session.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+	// 最初のハンドではスモールブラインドとビッグブラインドが支払われる
+	blinds := map[int]int{}
+	for _, entry := range table.Ledger().EntriesForHand(1) {
+		if entry.Type == LedgerBlind {
+			blinds[entry.Amount]++
+		}
+	}
+	if blinds[5] != 1 || blinds[10] != 1 {
+		t.Errorf("blinds in hand 1 = %v, want one 5 and one 10", blinds)
+	}
+	if err := table.Leave(players[0]); err != nil {
+		t.Fatal(err)
+	}
+	entries := table.Ledger().Entries()
+	last := entries[len(entries)-1]
+	if last.Type != LedgerCashOut || last.Player != players[0] || last.Sequence != len(entries) {
+		t.Errorf("last entry = %+v, want cash-out of players[0]", last)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() after leaving error = %v", err)
+	}
+}
+
+func TestTable_CheckConservation(t *testing.T) {
+	table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() during the hand error = %v", err)
+	}
+	// 台帳を通さずにチップを増やすと検出される
+	players[0].Win(10)
+	if err := table.CheckConservation(); err == nil {
+		t.Errorf("Table.CheckConservation() error = nil, want error")
+	}
+	if _, err := NewSession(table).playUntilComplete(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.EndHand(); err == nil {
+		t.Errorf("Table.EndHand() error = nil, want error")
+	}
+}
@@ -0,0 +1,150 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+type MisdealReason int
+
+const (
+	// 配っている途中でカードが表向きになった
+	MisdealExposedCard MisdealReason = iota
+	// 配ったカードの枚数が正しくない
+	MisdealWrongCardCount
+	// 空席にカードを配ろうとした
+	MisdealEmptySeat
+	// 山札に同じカードが含まれている
+	MisdealDuplicateCard
+)
+
+func (r MisdealReason) String() string {
+	switch r {
+	case MisdealExposedCard:
+		return "exposed card"
+	case MisdealWrongCardCount:
+		return "wrong number of cards"
+	case MisdealEmptySeat:
+		return "empty seat"
+	case MisdealDuplicateCard:
+		return "duplicate card"
+	default:
+		return "unknown"
+	}
+}
+
+// 1つのハンドで配り直せる回数。これを超えるミスディールはエラーにする
+const maxMisdeals = 3
+
+// ミスディールの記録
+type Misdeal struct {
+	HandNumber int
+	Reason     MisdealReason
+}
+
+// シットアウトから復帰したプレイヤーが支払う前のブラインド
+type missedBlinds struct {
+	small, big bool
+}
+
+// これまでに起きたミスディール
+func (t *Table) Misdeals() []Misdeal {
+	return append([]Misdeal{}, t.misdeals...)
+}
+
+// 配っている途中でカードが表向きになったことを報告する。誰もアクションしていなければミスディールとして配り直す
+func (t *Table) ReportExposedCard(player *entity.Player, card *valueobject.Card) error {
+	if err := t.requirePhase("report exposed card", PhaseBetting); err != nil {
+		return err
+	}
+	if t.bettingRound != 1 || len(t.acted) > 0 || len(t.folded) > 0 {
+		return fmt.Errorf("cannot declare a misdeal after action has started")
+	}
+	if !valueobject.ContainsCard(player.Cards(), card) {
+		return fmt.Errorf("card is not in the hand")
+	}
+	if err := t.misdeal(MisdealExposedCard); err != nil {
+		return err
+	}
+	return t.DealCards()
+}
+
+// 配る前に、山札とハンドに参加するプレイヤーにミスディールにあたる状態がないかを確かめる
+func (t *Table) checkDeck() (MisdealReason, bool) {
+	// 2組目のデッキを使っている場合は、同じカードが組数まであってよい
+	seen := map[string]int{}
+	for _, card := range t.deck {
+		key := card.Suit() + card.Value()
+		seen[key]++
+		if seen[key] > t.decks {
+			return MisdealDuplicateCard, true
+		}
+	}
+	for _, player := range t.players {
+		if _, err := t.SeatOf(player); err != nil {
+			return MisdealEmptySeat, true
+		}
+	}
+	return 0, false
+}
+
+// 配った後に、各プレイヤーの手札の枚数を確かめる
+func (t *Table) checkDealtCards(numberOfCards int) (MisdealReason, bool) {
+	for _, player := range t.players {
+		if len(player.Cards()) != numberOfCards {
+			return MisdealWrongCardCount, true
+		}
+	}
+	return 0, false
+}
+
+// ミスディールを記録してハンドを巻き戻し、アンティとブラインドを支払い直す
+// 配り直せる回数を超えた場合は、巻き戻したままハンドを中止し、StartHandで次のハンドを始められるようにする
+func (t *Table) misdeal(reason MisdealReason) error {
+	t.misdeals = append(t.misdeals, Misdeal{HandNumber: t.handNumber, Reason: reason})
+	count := 0
+	for _, misdeal := range t.misdeals {
+		if misdeal.HandNumber == t.handNumber {
+			count++
+		}
+	}
+	t.rollBackHand()
+	if count > maxMisdeals {
+		t.phase = PhaseWaiting
+		return fmt.Errorf("too many misdeals in hand %d: %s", t.handNumber, reason)
+	}
+	return t.PostBlinds()
+}
+
+// ハンドをアンティとブラインドを支払う前の状態に戻す。ポットに入れたチップは返し、カードを集めてシャッフルし直す
+// ボタンとブラインドの位置は変えない
+func (t *Table) rollBackHand() {
+	for _, player := range t.players {
+		if contribution := t.contributions[player]; contribution > 0 {
+			player.CollectChips()
+			player.Win(t.record(LedgerRefund, player, contribution))
+		}
+		player.ReturnCards()
+	}
+	t.pot = 0
+	for seat, missed := range t.missedBlinds {
+		seat.missedSmallBlind = missed.small
+		seat.missedBigBlind = missed.big
+	}
+	players := []*entity.Player{}
+	for _, player := range t.players {
+		if _, err := t.SeatOf(player); err == nil {
+			players = append(players, player)
+		}
+	}
+	t.players = players
+	t.collectCards()
+	t.folded = map[*entity.Player]bool{}
+	t.contributions = map[*entity.Player]int{}
+	t.deadMoney = map[*entity.Player]int{}
+	t.bettingRound = 0
+	t.straddler = nil
+	t.phase = PhasePosting
+}
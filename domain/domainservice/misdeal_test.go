@@ -0,0 +1,199 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// 各プレイヤーのスタックと掛け金
+func stacksAndChips(players []*entity.Player) [][2]int {
+	got := [][2]int{}
+	for _, player := range players {
+		got = append(got, [2]int{player.Stack(), player.Chips()})
+	}
+	return got
+}
+
+func TestTable_DealCards_Misdeal(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(table *Table, players []*entity.Player)
+		wantReason MisdealReason
+	}{
+		{
+			name: "山札に同じカードがあれば配り直す",
+			setup: func(table *Table, players []*entity.Player) {
+				table.deck[1] = valueobject.NewCard(table.deck[0].Suit(), table.deck[0].Value())
+			},
+			wantReason: MisdealDuplicateCard,
+		},
+		{
+			name: "配った枚数が正しくなければ配り直す",
+			setup: func(table *Table, players []*entity.Player) {
+				players[0].DrawCard(table.deck[len(table.deck)-1])
+				table.deck = table.deck[:len(table.deck)-1]
+			},
+			wantReason: MisdealWrongCardCount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100})
+			before := stacksAndChips(players)
+			tt.setup(table, players)
+			if err := table.DealCards(); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := table.Misdeals(), []Misdeal{{HandNumber: 1, Reason: tt.wantReason}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Table.Misdeals() = %v, want %v", got, want)
+			}
+			// ブラインドは返された後に支払い直される
+			if got := stacksAndChips(players); !reflect.DeepEqual(got, before) {
+				t.Errorf("stacks and chips = %v, want %v", got, before)
+			}
+			refunds := 0
+			for _, entry := range table.Ledger().EntriesForHand(1) {
+				if entry.Type == LedgerRefund {
+					refunds += entry.Amount
+				}
+			}
+			if refunds != 15 {
+				t.Errorf("refunds = %v, want 15", refunds)
+			}
+			for _, player := range players {
+				if got := len(player.Cards()); got != 5 {
+					t.Errorf("%s has %v cards, want 5", player.Name(), got)
+				}
+			}
+			if table.Phase() != PhaseBetting {
+				t.Errorf("Table.Phase() = %v, want %v", table.Phase(), PhaseBetting)
+			}
+			if err := table.CheckConservation(); err != nil {
+				t.Errorf("Table.CheckConservation() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTable_DealCards_EmptySeat(t *testing.T) {
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100})
+	table.seats[0] = &Seat{}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := table.Misdeals(), []Misdeal{{HandNumber: 1, Reason: MisdealEmptySeat}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.Misdeals() = %v, want %v", got, want)
+	}
+	if got, want := table.Players(), []*entity.Player{players[1], players[2]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.Players() = %v, want %v", got, want)
+	}
+}
+
+func TestTable_DealCards_NotEnoughCards(t *testing.T) {
+	table, _ := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100})
+	table.deck = table.deck[:14]
+	if err := table.DealCards(); err == nil {
+		t.Errorf("Table.DealCards() error = nil, want error")
+	}
+}
+
+func TestTable_ReportExposedCard(t *testing.T) {
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100})
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.ReportExposedCard(players[0], players[1].Cards()[0]); err == nil {
+		t.Errorf("Table.ReportExposedCard() error = nil for a card not in the hand, want error")
+	}
+	// 手札のカードと同じスートとランクであれば、別に作ったカードでも報告できる
+	exposed := valueobject.NewCard(players[1].Cards()[0].Suit(), players[1].Cards()[0].Value())
+	if err := table.ReportExposedCard(players[1], exposed); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := table.Misdeals(), []Misdeal{{HandNumber: 1, Reason: MisdealExposedCard}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.Misdeals() = %v, want %v", got, want)
+	}
+	if table.Phase() != PhaseBetting || table.Actor() != players[0] {
+		t.Errorf("Table.Phase() = %v, want %v with the first actor", table.Phase(), PhaseBetting)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+	if err := table.Act(table.Actor(), Action{Type: ActionCall}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.ReportExposedCard(players[1], players[1].Cards()[0]); err == nil {
+		t.Errorf("Table.ReportExposedCard() error = nil after action, want error")
+	}
+}
+
+func TestTable_ReportExposedCard_TooManyMisdeals(t *testing.T) {
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100})
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxMisdeals; i++ {
+		if err := table.ReportExposedCard(players[0], players[0].Cards()[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.ReportExposedCard(players[0], players[0].Cards()[0]); err == nil {
+		t.Errorf("Table.ReportExposedCard() error = nil, want error")
+	}
+	// ハンドは中止され、支払ったアンティとブラインドはすべて返される
+	for i, player := range players {
+		if player.Stack() != 100 || player.Chips() != 0 || len(player.Cards()) != 0 {
+			t.Errorf("players[%d] stack = %v, chips = %v, cards = %v, want 100, 0, 0", i, player.Stack(), player.Chips(), len(player.Cards()))
+		}
+	}
+	if table.Phase() != PhaseWaiting || table.Pot() != 0 {
+		t.Errorf("phase = %v, pot = %v, want %v, 0", table.Phase(), table.Pot(), PhaseWaiting)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Errorf("Table.StartHand() after too many misdeals error = %v", err)
+	}
+}
+
+func TestTable_Misdeal_RestoresMissedBlinds(t *testing.T) {
+	table, players := newSeatTestTable(t, 4, []int{0, 1, 2, 3})
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SitOut(players[3]); err != nil {
+		t.Fatal(err)
+	}
+	finishHand(t, table)
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SitIn(players[3]); err != nil {
+		t.Fatal(err)
+	}
+	finishHand(t, table)
+	// 復帰したプレイヤーが支払ったブラインドは、ミスディールで返された後にもう一度支払う
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	before := players[3].Stack()
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.ReportExposedCard(players[3], players[3].Cards()[0]); err != nil {
+		t.Fatal(err)
+	}
+	if paid := before - players[3].Stack(); paid != 15 {
+		t.Errorf("players[3] paid %v, want 15", paid)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// ポットオッズ (ポット : コール額) をポット / コール額として返す
+func (t *Table) PotOdds(player *entity.Player) (float64, error) {
+	amountToCall, err := t.AmountToCall(player)
+	if err != nil {
+		return 0, err
+	}
+	if amountToCall == 0 {
+		return 0, fmt.Errorf("nothing to call")
+	}
+	return float64(t.Pot()) / float64(amountToCall), nil
+}
+
+// コールが損にならないために必要なエクイティ。ポットもコール額も0なら求められない
+func (t *Table) RequiredEquity(player *entity.Player) (float64, error) {
+	amountToCall, err := t.AmountToCall(player)
+	if err != nil {
+		return 0, err
+	}
+	if t.Pot()+amountToCall == 0 {
+		return 0, fmt.Errorf("pot is empty and nothing to call")
+	}
+	return float64(amountToCall) / float64(t.Pot()+amountToCall), nil
+}
+
+// 今後の勝ち分futureWinningsを見込んだインプライドオッズ
+func (t *Table) ImpliedOdds(player *entity.Player, futureWinnings int) (float64, error) {
+	amountToCall, err := t.AmountToCall(player)
+	if err != nil {
+		return 0, err
+	}
+	if amountToCall == 0 {
+		return 0, fmt.Errorf("nothing to call")
+	}
+	return float64(t.Pot()+futureWinnings) / float64(amountToCall), nil
+}
+
+// フォールドの期待値。これ以上チップを失わないので0とする
+func (t *Table) FoldEV() float64 {
+	return 0
+}
+
+// エクイティequityでコールした場合の期待値
+func (t *Table) CallEV(player *entity.Player, equity float64) (float64, error) {
+	amountToCall, err := t.AmountToCall(player)
+	if err != nil {
+		return 0, err
+	}
+	return equity*float64(t.Pot()+amountToCall) - float64(amountToCall), nil
+}
+
+// raiseToまでレイズした場合の期待値
+// 相手はfoldEquityの確率でフォールドし、そうでなければ現在の最大の掛け金からraiseToまでコールするものとする
+func (t *Table) RaiseEV(player *entity.Player, raiseTo int, equity float64, foldEquity float64) (float64, error) {
+	if !t.isSeated(player) {
+		return 0, fmt.Errorf("player is not at the table")
+	}
+	if raiseTo <= t.CurrentBet() {
+		return 0, fmt.Errorf("raise must be greater than current bet %d", t.CurrentBet())
+	}
+	risk := raiseTo - player.Chips()
+	if risk > player.Stack() {
+		return 0, fmt.Errorf("not enough chips")
+	}
+	called := raiseTo - t.CurrentBet()
+	finalPot := t.Pot() + risk + called
+	return foldEquity*float64(t.Pot()) + (1-foldEquity)*(equity*float64(finalPot)-float64(risk)), nil
+}
@@ -0,0 +1,176 @@
+package domainservice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// ポット100に対して相手が50をベットしている状況を作る
+func newOddsTestTable(t *testing.T) (*Table, *entity.Player) {
+	t.Helper()
+	villain := newPlayerWithStack(t, "villain", 1000)
+	hero := newPlayerWithStack(t, "hero", 1000)
+	if err := villain.Bet(50); err != nil {
+		t.Fatal(err)
+	}
+	return &Table{players: []*entity.Player{villain, hero}, pot: 100}, hero
+}
+
+func TestTable_PotOdds(t *testing.T) {
+	table, hero := newOddsTestTable(t)
+	got, err := table.PotOdds(hero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 3 {
+		t.Errorf("Table.PotOdds() = %v, want 3", got)
+	}
+	required, err := table.RequiredEquity(hero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if required != 0.25 {
+		t.Errorf("Table.RequiredEquity() = %v, want 0.25", required)
+	}
+	implied, err := table.ImpliedOdds(hero, 150)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if implied != 6 {
+		t.Errorf("Table.ImpliedOdds() = %v, want 6", implied)
+	}
+}
+
+func TestTable_RequiredEquity(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   func(t *testing.T) (*Table, *entity.Player)
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:  "ポット100に対して50をコールする",
+			table: newOddsTestTable,
+			want:  0.25,
+		},
+		{
+			name: "ポットもコール額も0ならエラーになる",
+			table: func(t *testing.T) (*Table, *entity.Player) {
+				hero := newPlayerWithStack(t, "hero", 1000)
+				return &Table{players: []*entity.Player{newPlayerWithStack(t, "villain", 1000), hero}}, hero
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, hero := tt.table(t)
+			got, err := table.RequiredEquity(hero)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Table.RequiredEquity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Table.RequiredEquity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_CallEV(t *testing.T) {
+	tests := []struct {
+		name   string
+		equity float64
+		want   float64
+	}{
+		{
+			name:   "必要なエクイティちょうど",
+			equity: 0.25,
+			want:   0,
+		},
+		{
+			name:   "エクイティが高い",
+			equity: 0.5,
+			want:   50,
+		},
+		{
+			name:   "エクイティが低い",
+			equity: 0,
+			want:   -50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, hero := newOddsTestTable(t)
+			got, err := table.CallEV(hero, tt.equity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Table.CallEV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_RaiseEV(t *testing.T) {
+	tests := []struct {
+		name       string
+		raiseTo    int
+		equity     float64
+		foldEquity float64
+		want       float64
+		wantErr    bool
+	}{
+		{
+			name:       "必ずフォールドされる",
+			raiseTo:    200,
+			equity:     0,
+			foldEquity: 1,
+			want:       150,
+			wantErr:    false,
+		},
+		{
+			name:       "必ずコールされる",
+			raiseTo:    200,
+			equity:     0.5,
+			foldEquity: 0,
+			// 最終的なポットは150 + 200 + 150 = 500
+			want:    50,
+			wantErr: false,
+		},
+		{
+			name:       "現在の掛け金以下のレイズ",
+			raiseTo:    50,
+			equity:     0.5,
+			foldEquity: 0,
+			want:       0,
+			wantErr:    true,
+		},
+		{
+			name:       "スタックを超えるレイズ",
+			raiseTo:    2000,
+			equity:     0.5,
+			foldEquity: 0,
+			want:       0,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, hero := newOddsTestTable(t)
+			got, err := table.RaiseEV(hero, tt.raiseTo, tt.equity, tt.foldEquity)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.RaiseEV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Table.RaiseEV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := (&Table{}).FoldEV(); got != 0 {
+		t.Errorf("Table.FoldEV() = %v, want 0", got)
+	}
+}
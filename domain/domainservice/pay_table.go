@@ -0,0 +1,276 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ビデオポーカー独自の役
+const (
+	jacksOrBetter         = "ジャックスオアベター"
+	fourAces              = "エースのフォーカード"
+	fourTwosThroughFours  = "2〜4のフォーカード"
+	fourFivesThroughKings = "5〜Kのフォーカード"
+	fourDeuces            = "フォーデュース"
+	wildRoyalFlush        = "ワイルドロイヤルストレートフラッシュ"
+	fiveOfAKind           = "ファイブカード"
+)
+
+// ビデオポーカーの配当表。役ごとの1コインあたりの配当を持つ
+type PayTable struct {
+	name    string
+	payouts map[string]int
+	// ワンペアで配当を得るために必要な最小のランク。0ならワンペアは全て同じ役として扱う
+	minimumPairRank int
+	// フォーカードをランクによって区別するかどうか
+	bonusFourOfAKind bool
+	// ワイルドカードとして扱うランク。空文字ならワイルドカードなし
+	wildValue string
+}
+
+func NewPayTable(name string, payouts map[string]int, minimumPairRank int, bonusFourOfAKind bool, wildValue string) *PayTable {
+	return &PayTable{
+		name:             name,
+		payouts:          payouts,
+		minimumPairRank:  minimumPairRank,
+		bonusFourOfAKind: bonusFourOfAKind,
+		wildValue:        wildValue,
+	}
+}
+
+// 9/6 ジャックスオアベター
+func JacksOrBetterPayTable() *PayTable {
+	return NewPayTable("Jacks or Better", map[string]int{
+		"ロイヤルストレートフラッシュ": 800,
+		"ストレートフラッシュ":     50,
+		"フォーカード":         25,
+		"フルハウス":          9,
+		"フラッシュ":          6,
+		"ストレート":          4,
+		"スリーカード":         3,
+		"ツーペア":           2,
+		jacksOrBetter:    1,
+	}, valueobject.ValueRankMap()["J"], false, "")
+}
+
+// 8/5 ボーナスポーカー
+func BonusPokerPayTable() *PayTable {
+	return NewPayTable("Bonus Poker", map[string]int{
+		"ロイヤルストレートフラッシュ":      800,
+		"ストレートフラッシュ":          50,
+		fourAces:              80,
+		fourTwosThroughFours:  40,
+		fourFivesThroughKings: 25,
+		"フルハウス":               8,
+		"フラッシュ":               5,
+		"ストレート":               4,
+		"スリーカード":              3,
+		"ツーペア":                2,
+		jacksOrBetter:         1,
+	}, valueobject.ValueRankMap()["J"], true, "")
+}
+
+// フルペイのデュースワイルド
+func DeucesWildPayTable() *PayTable {
+	return NewPayTable("Deuces Wild", map[string]int{
+		"ロイヤルストレートフラッシュ": 800,
+		fourDeuces:     200,
+		wildRoyalFlush: 25,
+		fiveOfAKind:    15,
+		"ストレートフラッシュ":   9,
+		"フォーカード":       5,
+		"フルハウス":        3,
+		"フラッシュ":        2,
+		"ストレート":        2,
+		"スリーカード":       1,
+	}, 0, false, "2")
+}
+
+func (pt *PayTable) Name() string {
+	return pt.name
+}
+
+// 配当表における役を返す
+func (pt *PayTable) Classify(hand []*valueobject.Card) (string, error) {
+	if len(hand) != numberOfHandCards {
+		return "", fmt.Errorf("number of cards is not %d", numberOfHandCards)
+	}
+	if pt.wildValue != "" {
+		wilds := 0
+		for _, card := range hand {
+			if card.Value() == pt.wildValue {
+				wilds++
+			}
+		}
+		if wilds > 0 {
+			return pt.classifyWild(hand, wilds), nil
+		}
+	}
+	value, err := entity.EvaluateHand(hand)
+	if err != nil {
+		return "", err
+	}
+	player, err := entity.NewPlayer("", 0)
+	if err != nil {
+		return "", err
+	}
+	for _, card := range hand {
+		player.DrawCard(card)
+	}
+	switch value.Hand() {
+	case "ワンペア":
+		if pt.minimumPairRank == 0 {
+			return value.Hand(), nil
+		}
+		onePair, err := player.SeparateOnePairAndOtherCards()
+		if err != nil {
+			return "", err
+		}
+		if valueobject.ValueRankMap()[onePair[0][0].Value()] >= pt.minimumPairRank {
+			return jacksOrBetter, nil
+		}
+		return "ハイカード", nil
+	case "フォーカード":
+		if !pt.bonusFourOfAKind {
+			return value.Hand(), nil
+		}
+		fourCards, err := player.SeparateFourOfAKindAndOtherCard()
+		if err != nil {
+			return "", err
+		}
+		rank := valueobject.ValueRankMap()[fourCards[0][0].Value()]
+		switch {
+		case rank == valueobject.ValueRankMap()["A"]:
+			return fourAces, nil
+		case rank <= valueobject.ValueRankMap()["4"]:
+			return fourTwosThroughFours, nil
+		default:
+			return fourFivesThroughKings, nil
+		}
+	}
+	return value.Hand(), nil
+}
+
+// ワイルドカードを含むハンドの役を、作れる最も強い役として判定する
+func (pt *PayTable) classifyWild(hand []*valueobject.Card, wilds int) string {
+	if wilds == 4 {
+		return fourDeuces
+	}
+	naturals := []*valueobject.Card{}
+	counts := map[int]int{}
+	for _, card := range hand {
+		if card.Value() != pt.wildValue {
+			naturals = append(naturals, card)
+			counts[valueobject.ValueRankMap()[card.Value()]]++
+		}
+	}
+	maxCount := 0
+	pairs := 0
+	for _, count := range counts {
+		if count > maxCount {
+			maxCount = count
+		}
+		if count == 2 {
+			pairs++
+		}
+	}
+	sameSuit := true
+	for _, card := range naturals {
+		if card.Suit() != naturals[0].Suit() {
+			sameSuit = false
+		}
+	}
+	distinct := len(counts) == len(naturals)
+	switch {
+	case distinct && sameSuit && fitsRoyal(counts):
+		return wildRoyalFlush
+	case maxCount+wilds >= 5:
+		return fiveOfAKind
+	case distinct && sameSuit && fitsStraight(counts):
+		return "ストレートフラッシュ"
+	case maxCount+wilds >= 4:
+		return "フォーカード"
+	case wilds == 1 && pairs == 2:
+		return "フルハウス"
+	case sameSuit:
+		return "フラッシュ"
+	case distinct && fitsStraight(counts):
+		return "ストレート"
+	case maxCount+wilds >= 3:
+		return "スリーカード"
+	}
+	return "ワンペア"
+}
+
+// 10からAまでの5枚に収まるかどうか
+func fitsRoyal(counts map[int]int) bool {
+	for rank := range counts {
+		if rank < valueobject.ValueRankMap()["10"] {
+			return false
+		}
+	}
+	return true
+}
+
+// ワイルドカードで補って5枚連続にできるかどうか
+func fitsStraight(counts map[int]int) bool {
+	for low := 1; low <= valueobject.ValueRankMap()["10"]; low++ {
+		fits := true
+		for rank := range counts {
+			r := rank
+			// A, 2, 3, 4, 5のストレートではAを1として扱う
+			if rank == valueobject.ValueRankMap()["A"] && low == 1 {
+				r = 1
+			}
+			if r < low || r > low+4 {
+				fits = false
+			}
+		}
+		if fits {
+			return true
+		}
+	}
+	return false
+}
+
+// 1コインあたりの配当を返す
+func (pt *PayTable) Payout(hand []*valueobject.Card) (int, error) {
+	category, err := pt.Classify(hand)
+	if err != nil {
+		return 0, err
+	}
+	return pt.payouts[category], nil
+}
+
+// 期待値が最も高くなる残し方を返す
+// 交換後の組み合わせがsamplesを超える場合は無作為に引いて推定する
+func (pt *PayTable) OptimalHold(hand []*valueobject.Card, samples int) (DrawOption, error) {
+	payoff := func(final []*valueobject.Card) (float64, error) {
+		payout, err := pt.Payout(final)
+		return float64(payout), err
+	}
+	options, err := NewDiscardAdvisor(nil, payoff, samples).Advise(hand, nil, ByExpectedValue)
+	if err != nil {
+		return DrawOption{}, err
+	}
+	return options[0], nil
+}
+
+// 最適な戦略で遊んだ場合のペイアウト率を、hands回の配り直しで推定する
+func (pt *PayTable) ReturnToPlayer(hands int, samples int) (float64, error) {
+	if hands <= 0 {
+		return 0, fmt.Errorf("hands must be positive")
+	}
+	total := 0.0
+	for i := 0; i < hands; i++ {
+		hand := shuffleDeck(createDeck())[:numberOfHandCards]
+		option, err := pt.OptimalHold(hand, samples)
+		if err != nil {
+			return 0, err
+		}
+		total += option.ExpectedValue
+	}
+	return total / float64(hands), nil
+}
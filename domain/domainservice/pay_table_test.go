@@ -0,0 +1,195 @@
+package domainservice
+
+import (
+	"testing"
+)
+
+func TestPayTable_Classify(t *testing.T) {
+	tests := []struct {
+		name     string
+		payTable *PayTable
+		hand     string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "ジャックスオアベター/Jのペア",
+			payTable: JacksOrBetterPayTable(),
+			hand:     "JsJh2d5c7h",
+			want:     jacksOrBetter,
+			wantErr:  false,
+		},
+		{
+			name:     "ジャックスオアベター/10のペアは配当なし",
+			payTable: JacksOrBetterPayTable(),
+			hand:     "TsTh2d5c7h",
+			want:     "ハイカード",
+			wantErr:  false,
+		},
+		{
+			name:     "ジャックスオアベター/ツーペア",
+			payTable: JacksOrBetterPayTable(),
+			hand:     "TsTh2d2c7h",
+			want:     "ツーペア",
+			wantErr:  false,
+		},
+		{
+			name:     "ボーナスポーカー/エースのフォーカード",
+			payTable: BonusPokerPayTable(),
+			hand:     "AsAhAdAc7h",
+			want:     fourAces,
+			wantErr:  false,
+		},
+		{
+			name:     "ボーナスポーカー/3のフォーカード",
+			payTable: BonusPokerPayTable(),
+			hand:     "3s3h3d3c7h",
+			want:     fourTwosThroughFours,
+			wantErr:  false,
+		},
+		{
+			name:     "ボーナスポーカー/9のフォーカード",
+			payTable: BonusPokerPayTable(),
+			hand:     "9s9h9d9c7h",
+			want:     fourFivesThroughKings,
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/フォーデュース",
+			payTable: DeucesWildPayTable(),
+			hand:     "2s2h2d2c7h",
+			want:     fourDeuces,
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/ワイルドロイヤル",
+			payTable: DeucesWildPayTable(),
+			hand:     "AsKs2dJsTs",
+			want:     wildRoyalFlush,
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/ナチュラルロイヤル",
+			payTable: DeucesWildPayTable(),
+			hand:     "AsKsQsJsTs",
+			want:     "ロイヤルストレートフラッシュ",
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/ファイブカード",
+			payTable: DeucesWildPayTable(),
+			hand:     "7s7h2d7c2h",
+			want:     fiveOfAKind,
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/A,2,3,4,5のストレートフラッシュ",
+			payTable: DeucesWildPayTable(),
+			hand:     "As3s2d5s4s",
+			want:     "ストレートフラッシュ",
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/ツーペアとワイルドでフルハウス",
+			payTable: DeucesWildPayTable(),
+			hand:     "7s7h9d9c2h",
+			want:     "フルハウス",
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/ガットショットをワイルドで埋める",
+			payTable: DeucesWildPayTable(),
+			hand:     "5s6h2d8c9h",
+			want:     "ストレート",
+			wantErr:  false,
+		},
+		{
+			name:     "デュースワイルド/ワイルド1枚はワンペア",
+			payTable: DeucesWildPayTable(),
+			hand:     "5s6h2dJcKh",
+			want:     "ワンペア",
+			wantErr:  false,
+		},
+		{
+			name:     "枚数不足",
+			payTable: JacksOrBetterPayTable(),
+			hand:     "5s6h2dJc",
+			want:     "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.payTable.Classify(mustParseCards(t, tt.hand))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PayTable.Classify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PayTable.Classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayTable_OptimalHold(t *testing.T) {
+	tests := []struct {
+		name     string
+		payTable *PayTable
+		hand     string
+		wantHold string
+	}{
+		{
+			name:     "ロイヤルストレートフラッシュは全て残す",
+			payTable: JacksOrBetterPayTable(),
+			hand:     "AsKsQsJsTs",
+			wantHold: "AsKsQsJsTs",
+		},
+		{
+			name:     "ロイヤルドローはペアより優先する",
+			payTable: JacksOrBetterPayTable(),
+			hand:     "AsKsQsJsJh",
+			wantHold: "AsKsQsJs",
+		},
+		{
+			name:     "デュースワイルドではデュースを残す",
+			payTable: DeucesWildPayTable(),
+			hand:     "2s2h2d7c9h",
+			wantHold: "2s2h2d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.payTable.OptimalHold(mustParseCards(t, tt.hand), 2000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := mustParseCards(t, tt.wantHold)
+			if len(got.Hold) != len(want) {
+				t.Fatalf("PayTable.OptimalHold().Hold = %v, want %v", got.Hold, want)
+			}
+			if findDrawOption([]DrawOption{got}, want) == nil {
+				t.Errorf("PayTable.OptimalHold().Hold = %v, want %v", got.Hold, want)
+			}
+		})
+	}
+}
+
+func TestPayTable_ReturnToPlayer(t *testing.T) {
+	// どんなハンドでも1コイン返す配当表のペイアウト率は100%になる
+	payouts := map[string]int{}
+	for hand := range map[string]bool{"ハイカード": true, "ワンペア": true, "ツーペア": true, "スリーカード": true, "ストレート": true, "フラッシュ": true, "フルハウス": true, "フォーカード": true, "ストレートフラッシュ": true, "ロイヤルストレートフラッシュ": true} {
+		payouts[hand] = 1
+	}
+	payTable := NewPayTable("flat", payouts, 0, false, "")
+	got, err := payTable.ReturnToPlayer(3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 1 {
+		t.Errorf("PayTable.ReturnToPlayer() = %v, want 1", got)
+	}
+	if _, err := payTable.ReturnToPlayer(0, 10); err == nil {
+		t.Errorf("PayTable.ReturnToPlayer() error = nil, want error")
+	}
+}
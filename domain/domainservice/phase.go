@@ -0,0 +1,101 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// ハンドの進行状況
+type Phase int
+
+const (
+	// ハンドが始まるのを待っている
+	PhaseWaiting Phase = iota
+	// アンティとブラインドの支払い
+	PhasePosting
+	// カードを配る
+	PhaseDealing
+	// ベッティングラウンド。何回目かはBettingRoundで分かる
+	PhaseBetting
+	// カードの交換
+	PhaseDraw
+	// 手札を比べて勝者を決める
+	PhaseShowdown
+	// 勝者にチップを配る
+	PhasePayout
+	// ハンドが終わり、席の変更を反映するのを待っている
+	PhaseComplete
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseWaiting:
+		return "waiting"
+	case PhasePosting:
+		return "posting"
+	case PhaseDealing:
+		return "dealing"
+	case PhaseBetting:
+		return "betting"
+	case PhaseDraw:
+		return "draw"
+	case PhaseShowdown:
+		return "showdown"
+	case PhasePayout:
+		return "payout"
+	case PhaseComplete:
+		return "complete"
+	default:
+		return "unknown"
+	}
+}
+
+func (t *Table) Phase() Phase {
+	return t.phase
+}
+
+// 現在のベッティングラウンドの番号。ドロー前が1、ドロー後が2
+func (t *Table) BettingRound() int {
+	return t.bettingRound
+}
+
+func (t *Table) IsHandInProgress() bool {
+	return t.phase != PhaseWaiting
+}
+
+// 現在のフェーズがallowedのいずれかであることを確かめる
+func (t *Table) requirePhase(operation string, allowed ...Phase) error {
+	for _, phase := range allowed {
+		if t.phase == phase {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot %s in %s phase", operation, t.phase)
+}
+
+// プレイヤーが現在とれるアクション。UIに表示するボタンの判定に使う
+func (t *Table) PermittedActions(player *entity.Player) []ActionType {
+	switch t.phase {
+	case PhaseBetting:
+		if t.Actor() != player {
+			return nil
+		}
+		return t.permittedBettingActions(player)
+	case PhaseDraw:
+		if t.Drawer() != player {
+			return nil
+		}
+		return []ActionType{ActionDraw}
+	case PhaseShowdown:
+		if t.Showdowner() != player {
+			return nil
+		}
+		if !t.canMuck() {
+			return []ActionType{ActionShow}
+		}
+		return []ActionType{ActionShow, ActionMuck}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_PhaseTransitions(t *testing.T) {
+	table, _ := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if table.Phase() != PhaseWaiting {
+		t.Fatalf("Table.Phase() = %v, want %v", table.Phase(), PhaseWaiting)
+	}
+	if err := table.DealCards(); err == nil {
+		t.Errorf("Table.DealCards() before StartHand error = nil, want error")
+	}
+	if _, err := table.JudgeWinner(); err == nil {
+		t.Errorf("Table.JudgeWinner() before DealCards error = nil, want error")
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if table.Phase() != PhasePosting {
+		t.Errorf("Table.Phase() = %v, want %v", table.Phase(), PhasePosting)
+	}
+	if err := table.StartHand(); err == nil {
+		t.Errorf("Table.StartHand() twice error = nil, want error")
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if table.Phase() != PhaseDealing {
+		t.Errorf("Table.Phase() = %v, want %v", table.Phase(), PhaseDealing)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	if table.Phase() != PhaseBetting || table.BettingRound() != 1 {
+		t.Errorf("Table.Phase() = %v round %v, want %v round 1", table.Phase(), table.BettingRound(), PhaseBetting)
+	}
+	if err := table.DealCards(); err == nil {
+		t.Errorf("Table.DealCards() twice error = nil, want error")
+	}
+	if err := table.EndHand(); err == nil {
+		t.Errorf("Table.EndHand() during betting error = nil, want error")
+	}
+	finishHand(t, table)
+	if table.Phase() != PhaseWaiting {
+		t.Errorf("Table.Phase() = %v, want %v", table.Phase(), PhaseWaiting)
+	}
+}
+
+func TestTable_PermittedActions(t *testing.T) {
+	table, players := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if got := table.PermittedActions(players[0]); got != nil {
+		t.Errorf("Table.PermittedActions() before the hand = %v, want nil", got)
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	// ボタンがビッグブラインドの次なので最初にアクションする
+	if table.Actor() != players[0] {
+		t.Fatalf("Table.Actor() = %v, want players[0]", table.Actor())
+	}
+	want := []ActionType{ActionFold, ActionCall, ActionRaise, ActionAllIn}
+	if got := table.PermittedActions(players[0]); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PermittedActions() = %v, want %v", got, want)
+	}
+	if got := table.PermittedActions(players[1]); got != nil {
+		t.Errorf("Table.PermittedActions() for a player out of turn = %v, want nil", got)
+	}
+	if err := table.Act(players[0], Action{Type: ActionCall}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Act(players[1], Action{Type: ActionCall}); err != nil {
+		t.Fatal(err)
+	}
+	want = []ActionType{ActionFold, ActionCheck, ActionRaise, ActionAllIn}
+	if got := table.PermittedActions(players[2]); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PermittedActions() for the big blind = %v, want %v", got, want)
+	}
+	if err := table.Act(players[2], Action{Type: ActionCheck}); err != nil {
+		t.Fatal(err)
+	}
+	if table.Phase() != PhaseDraw {
+		t.Fatalf("Table.Phase() = %v, want %v", table.Phase(), PhaseDraw)
+	}
+	want = []ActionType{ActionDraw}
+	if got := table.PermittedActions(table.Drawer()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PermittedActions() during draw = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+package domainservice
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// 現在のポットの額。集めたチップと、現在のベッティングラウンドの掛け金の合計
+func (t *Table) Pot() int {
+	pot := t.pot
+	for _, player := range t.players {
+		pot += player.Chips()
+	}
+	return pot
+}
+
+// 現在のベッティングラウンドで最も大きい掛け金
+func (t *Table) CurrentBet() int {
+	currentBet := 0
+	for _, player := range t.players {
+		if player.Chips() > currentBet {
+			currentBet = player.Chips()
+		}
+	}
+	return currentBet
+}
+
+// コールに必要な額。スタックが足りない場合はスタックの全額
+func (t *Table) AmountToCall(player *entity.Player) (int, error) {
+	if !t.isSeated(player) {
+		return 0, fmt.Errorf("player is not at the table")
+	}
+	amount := t.CurrentBet() - player.Chips()
+	if amount > player.Stack() {
+		amount = player.Stack()
+	}
+	return amount, nil
+}
+
+// ベッティングラウンドの終わりに各プレイヤーの掛け金をポットに集める
+func (t *Table) CollectBets() {
+	for _, player := range t.players {
+		t.pot += player.CollectChips()
+	}
+}
+
+// メインポットやサイドポット。Eligibleはこのポットを獲得できるプレイヤー
+type SidePot struct {
+	Amount   int
+	Eligible []*entity.Player
+}
+
+// 現在のハンドのポットを、オールインしたプレイヤーの額ごとにメインポットとサイドポットに分ける
+// フォールドしたプレイヤーが入れた額はそれぞれのポットにデッドマネーとして入る
+// アンティやデッドのスモールブラインドは誰の掛け金にも対応しないので、すべてメインポットに入る
+func (t *Table) Pots() []SidePot {
+	levels := []int{}
+	for _, player := range t.RemainingPlayers() {
+		if contribution := t.liveContribution(player); contribution > 0 && !containsInt(levels, contribution) {
+			levels = append(levels, contribution)
+		}
+	}
+	sort.Ints(levels)
+	pots := []SidePot{}
+	previous := 0
+	for _, level := range levels {
+		pot := SidePot{}
+		for player := range t.contributions {
+			contribution := t.liveContribution(player)
+			pot.Amount += min(contribution, level) - min(contribution, previous)
+		}
+		for _, player := range t.RemainingPlayers() {
+			if t.liveContribution(player) >= level {
+				pot.Eligible = append(pot.Eligible, player)
+			}
+		}
+		pots = append(pots, pot)
+		previous = level
+	}
+	if len(pots) == 0 {
+		return []SidePot{{Amount: t.Pot(), Eligible: t.RemainingPlayers()}}
+	}
+	dead := 0
+	for _, amount := range t.deadMoney {
+		dead += amount
+	}
+	// 掛け金を入れずにオールインしたプレイヤーがいれば、デッドマネーだけのポットを残ったプレイヤー全員で争う
+	if dead > 0 && len(pots[0].Eligible) < len(t.RemainingPlayers()) {
+		pots = append([]SidePot{{Amount: dead, Eligible: t.RemainingPlayers()}}, pots...)
+	} else {
+		pots[0].Amount += dead
+	}
+	// 残ったプレイヤーの誰よりも多く入れてフォールドしたプレイヤーの額は最後のポットに入れる
+	total := 0
+	for _, pot := range pots {
+		total += pot.Amount
+	}
+	pots[len(pots)-1].Amount += t.Pot() - total
+	return pots
+}
+
+// プレイヤーが掛け金としてポットに入れた額。デッドマネーは含まない
+func (t *Table) liveContribution(player *entity.Player) int {
+	return t.contributions[player] - t.deadMoney[player]
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Table) isSeated(player *entity.Player) bool {
+	for _, p := range t.players {
+		if p == player {
+			return true
+		}
+	}
+	return false
+}
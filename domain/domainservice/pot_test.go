@@ -0,0 +1,203 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+func TestTable_Pot(t *testing.T) {
+	alice := newPlayerWithStack(t, "alice", 100)
+	bob := newPlayerWithStack(t, "bob", 100)
+	table := &Table{players: []*entity.Player{alice, bob}, pot: 30}
+	if err := alice.Bet(20); err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Pot(); got != 50 {
+		t.Errorf("Table.Pot() = %v, want 50", got)
+	}
+	if got := table.CurrentBet(); got != 20 {
+		t.Errorf("Table.CurrentBet() = %v, want 20", got)
+	}
+	table.CollectBets()
+	if got := table.Pot(); got != 50 {
+		t.Errorf("Table.Pot() after CollectBets() = %v, want 50", got)
+	}
+	if got := table.CurrentBet(); got != 0 {
+		t.Errorf("Table.CurrentBet() after CollectBets() = %v, want 0", got)
+	}
+}
+
+func TestTable_AmountToCall(t *testing.T) {
+	tests := []struct {
+		name    string
+		bets    []int
+		stacks  []int
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "差額をコールする",
+			bets:    []int{50, 20},
+			stacks:  []int{100, 100},
+			want:    30,
+			wantErr: false,
+		},
+		{
+			name:    "スタックが足りない場合はオールイン",
+			bets:    []int{50, 0},
+			stacks:  []int{100, 10},
+			want:    10,
+			wantErr: false,
+		},
+		{
+			name:    "コール不要",
+			bets:    []int{0, 0},
+			stacks:  []int{100, 100},
+			want:    0,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := []*entity.Player{}
+			for i, bet := range tt.bets {
+				player := newPlayerWithStack(t, "player", tt.stacks[i]+bet)
+				if err := player.Bet(bet); err != nil {
+					t.Fatal(err)
+				}
+				players = append(players, player)
+			}
+			table := &Table{players: players}
+			got, err := table.AmountToCall(players[1])
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.AmountToCall() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Table.AmountToCall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	table := &Table{}
+	if _, err := table.AmountToCall(newTestPlayer(t, "stranger", 100)); err == nil {
+		t.Errorf("Table.AmountToCall() error = nil, want error")
+	}
+}
+
+func TestTable_Pots(t *testing.T) {
+	table, players := newShowdownTestTable(t, []int{50, 100, 150}, []string{"AsKsQsJsTs", "2c2d7h8h9d", "3c5d7c9hJd"})
+	want := []SidePot{
+		{Amount: 150, Eligible: []*entity.Player{players[1], players[2], players[0]}},
+		{Amount: 100, Eligible: []*entity.Player{players[1], players[2]}},
+	}
+	if got := table.Pots(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.Pots() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTable_DistributeChips_SidePots(t *testing.T) {
+	tests := []struct {
+		name       string
+		stacks     []int
+		hands      []string
+		wantStacks []int
+	}{
+		{
+			name:       "最も短いスタックがメインポットだけを獲得する",
+			stacks:     []int{50, 100, 150},
+			hands:      []string{"AsKsQsJsTs", "2c2d7h8h9d", "3c5d7c9hJd"},
+			wantStacks: []int{150, 100, 50},
+		},
+		{
+			name:       "最も長いスタックが勝てばすべて獲得し、コールされなかった額は戻る",
+			stacks:     []int{50, 100, 150},
+			hands:      []string{"2c2d7h8h9d", "3c5d7c9hJd", "AsKsQsJsTs"},
+			wantStacks: []int{0, 0, 300},
+		},
+		{
+			name:       "サイドポットの引き分けは端数を並び順に配る",
+			stacks:     []int{50, 101, 101},
+			hands:      []string{"AsKsQsJsTs", "2c2d7h8h9d", "2h2s7c8c9s"},
+			wantStacks: []int{150, 51, 51},
+		},
+		{
+			name:       "4人のオールインで3つのポットを別々の勝者が獲得する",
+			stacks:     []int{30, 60, 90, 120},
+			hands:      []string{"AsKsQsJsTs", "KcKdKh2c3d", "QcQd4h5c7d", "3c5d7c9hJd"},
+			wantStacks: []int{120, 90, 60, 30},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newShowdownTestTable(t, tt.stacks, tt.hands)
+			winners, err := table.JudgeWinner()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := table.DistributeChips(winners); err != nil {
+				t.Fatal(err)
+			}
+			got := []int{}
+			for _, player := range players {
+				got = append(got, player.Stack())
+			}
+			if !reflect.DeepEqual(got, tt.wantStacks) {
+				t.Errorf("stacks = %v, want %v", got, tt.wantStacks)
+			}
+			if err := table.CheckConservation(); err != nil {
+				t.Errorf("Table.CheckConservation() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTable_Pots_DeadMoney(t *testing.T) {
+	tests := []struct {
+		name   string
+		stacks []int
+		blinds Blinds
+		want   func(players []*entity.Player) []SidePot
+	}{
+		{
+			name:   "オールインしたビッグブラインドが支払ったテーブルのアンティはメインポットに入る",
+			stacks: []int{100, 100, 25},
+			blinds: Blinds{SmallBlind: 5, BigBlind: 10, Ante: 5, AnteType: BigBlindAnte},
+			want: func(players []*entity.Player) []SidePot {
+				return []SidePot{{Amount: 45, Eligible: []*entity.Player{players[1], players[2], players[0]}}}
+			},
+		},
+		{
+			name:   "アンティだけでオールインしたプレイヤーはアンティのポットだけを争う",
+			stacks: []int{5, 100, 100},
+			blinds: Blinds{SmallBlind: 5, BigBlind: 10, Ante: 5, AnteType: PerPlayerAnte},
+			want: func(players []*entity.Player) []SidePot {
+				return []SidePot{
+					{Amount: 15, Eligible: []*entity.Player{players[1], players[2], players[0]}},
+					{Amount: 20, Eligible: []*entity.Player{players[1], players[2]}},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newPostedTestTable(t, tt.blinds, tt.stacks)
+			if err := table.DealCards(); err != nil {
+				t.Fatal(err)
+			}
+			for table.Phase() == PhaseBetting {
+				action := Action{Type: ActionCall}
+				if containsAction(table.PermittedActions(table.Actor()), ActionCheck) {
+					action = Action{Type: ActionCheck}
+				}
+				if err := table.Act(table.Actor(), action); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got, want := table.Pots(), tt.want(players); !reflect.DeepEqual(got, want) {
+				t.Errorf("Table.Pots() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
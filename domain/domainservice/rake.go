@@ -0,0 +1,134 @@
+package domainservice
+
+import (
+	"fmt"
+	"time"
+)
+
+// ポットから取る手数料の設定
+type Rake struct {
+	// ポットに対する割合。1万分率で、5%なら500
+	BasisPoints int
+	// 1ハンドで取る額の上限。0なら上限なし
+	Cap int
+	// ハンドに参加した人数ごとの上限。その人数以下で最も多い人数の上限を使い、該当がなければCapを使う
+	CapsByPlayers map[int]int
+	// この額に満たないポットからは取らない
+	MinimumPot int
+	// ドローの前にハンドが終わった場合は取らない (ノーフロップ・ノードロップ)
+	NoDrawNoDrop bool
+}
+
+// ポットから取る代わりに、席にいるプレイヤーから一定時間ごとに取る手数料 (タイムチャージ)
+type TimeCollection struct {
+	Amount   int
+	Interval time.Duration
+}
+
+func (t *Table) Rake() Rake {
+	return t.rake
+}
+
+func (t *Table) SetRake(rake Rake) error {
+	if rake.BasisPoints < 0 || rake.BasisPoints > 10000 {
+		return fmt.Errorf("rake must be between 0 and 10000 basis points")
+	}
+	if rake.Cap < 0 || rake.MinimumPot < 0 {
+		return fmt.Errorf("rake cap and minimum pot must not be negative")
+	}
+	for players, limit := range rake.CapsByPlayers {
+		if players < minSeats || limit < 0 {
+			return fmt.Errorf("invalid rake cap %d for %d players", limit, players)
+		}
+	}
+	if rake.BasisPoints > 0 && t.timeCollection.Amount > 0 {
+		return fmt.Errorf("rake cannot be combined with time collection")
+	}
+	t.rake = rake
+	return nil
+}
+
+func (t *Table) TimeCollection() TimeCollection {
+	return t.timeCollection
+}
+
+func (t *Table) SetTimeCollection(collection TimeCollection) error {
+	if collection.Amount < 0 {
+		return fmt.Errorf("time collection must not be negative")
+	}
+	if collection.Amount > 0 && collection.Interval <= 0 {
+		return fmt.Errorf("time collection interval must be positive")
+	}
+	if collection.Amount > 0 && t.rake.BasisPoints > 0 {
+		return fmt.Errorf("time collection cannot be combined with rake")
+	}
+	t.timeCollection = collection
+	return nil
+}
+
+// 現在のハンドでポットから取った手数料
+func (t *Table) HandRake() int {
+	return t.handRake
+}
+
+// ポットから取る手数料の額
+func (t *Table) calculateRake(pot int) int {
+	rake := t.rake
+	if rake.BasisPoints == 0 || pot < rake.MinimumPot {
+		return 0
+	}
+	if rake.NoDrawNoDrop && t.bettingRound < 2 {
+		return 0
+	}
+	amount := pot * rake.BasisPoints / 10000
+	if limit := t.rakeCap(len(t.players)); limit > 0 && amount > limit {
+		amount = limit
+	}
+	return amount
+}
+
+// ハンドに参加した人数に応じた手数料の上限
+func (t *Table) rakeCap(players int) int {
+	best := -1
+	for n := range t.rake.CapsByPlayers {
+		if n <= players && n > best {
+			best = n
+		}
+	}
+	if best < 0 {
+		return t.rake.Cap
+	}
+	return t.rake.CapsByPlayers[best]
+}
+
+// ポットから手数料を取り、台帳に記録する。手数料はメインポットから順に差し引く
+func (t *Table) takeRake(pots []SidePot) {
+	t.handRake = t.record(LedgerRake, nil, t.calculateRake(t.pot))
+	t.pot -= t.handRake
+	remaining := t.handRake
+	for i := range pots {
+		taken := min(remaining, pots[i].Amount)
+		pots[i].Amount -= taken
+		remaining -= taken
+	}
+}
+
+// 前回の徴収から一定時間が経ったプレイヤーからタイムチャージを取る
+func (t *Table) collectTime() {
+	if t.timeCollection.Amount == 0 {
+		return
+	}
+	now := t.clock()
+	for seatNumber, seat := range t.seats {
+		if !t.canTakeBigBlind(seatNumber) {
+			continue
+		}
+		collectedAt, ok := t.timeCollectedAt[seat.player]
+		if ok && now.Sub(collectedAt) < t.timeCollection.Interval {
+			continue
+		}
+		amount := min(t.timeCollection.Amount, seat.player.Stack())
+		t.record(LedgerTimeCollection, seat.player, seat.player.PostAnte(amount))
+		t.timeCollectedAt[seat.player] = now
+	}
+}
@@ -0,0 +1,183 @@
+package domainservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+func TestTable_calculateRake(t *testing.T) {
+	tests := []struct {
+		name         string
+		rake         Rake
+		pot          int
+		players      int
+		bettingRound int
+		want         int
+	}{
+		{
+			name:         "ポットの5%",
+			rake:         Rake{BasisPoints: 500},
+			pot:          210,
+			players:      3,
+			bettingRound: 2,
+			want:         10,
+		},
+		{
+			name:         "上限で止まる",
+			rake:         Rake{BasisPoints: 500, Cap: 3},
+			pot:          200,
+			players:      3,
+			bettingRound: 2,
+			want:         3,
+		},
+		{
+			name:         "人数ごとの上限",
+			rake:         Rake{BasisPoints: 500, Cap: 8, CapsByPlayers: map[int]int{2: 1, 4: 5}},
+			pot:          200,
+			players:      3,
+			bettingRound: 2,
+			want:         1,
+		},
+		{
+			name:         "人数ごとの上限に該当しなければ共通の上限",
+			rake:         Rake{BasisPoints: 500, Cap: 8, CapsByPlayers: map[int]int{4: 5}},
+			pot:          200,
+			players:      3,
+			bettingRound: 2,
+			want:         8,
+		},
+		{
+			name:         "最低額に満たないポット",
+			rake:         Rake{BasisPoints: 500, MinimumPot: 100},
+			pot:          50,
+			players:      3,
+			bettingRound: 2,
+			want:         0,
+		},
+		{
+			name:         "ドローの前に終わったハンド",
+			rake:         Rake{BasisPoints: 500, NoDrawNoDrop: true},
+			pot:          200,
+			players:      3,
+			bettingRound: 1,
+			want:         0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newTable("table", tt.players)
+			if err := table.SetRake(tt.rake); err != nil {
+				t.Fatal(err)
+			}
+			table.players = make([]*entity.Player, tt.players)
+			table.bettingRound = tt.bettingRound
+			if got := table.calculateRake(tt.pot); got != tt.want {
+				t.Errorf("Table.calculateRake() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_DistributeChips_Rake(t *testing.T) {
+	table, _ := newSeatTestTable(t, 3, []int{0, 1, 2})
+	if err := table.SetRake(Rake{BasisPoints: 1000, Cap: 2}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := NewSession(table).PlayHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.HandRake() != 2 {
+		t.Errorf("Table.HandRake() = %v, want 2", table.HandRake())
+	}
+	awarded := 0
+	for _, entry := range table.Ledger().EntriesForHand(1) {
+		if entry.Type == LedgerAward {
+			awarded += entry.Amount
+		}
+	}
+	if awarded != result.Pot-2 {
+		t.Errorf("awarded = %v, want %v", awarded, result.Pot-2)
+	}
+	if got := table.Ledger().Balance(); got != 298 {
+		t.Errorf("Ledger.Balance() = %v, want 298", got)
+	}
+}
+
+func TestTable_TimeCollection(t *testing.T) {
+	table, _ := newSeatTestTable(t, 3, []int{0, 1, 2})
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	table.clock = func() time.Time { return now }
+	if err := table.SetTimeCollection(TimeCollection{Amount: 6, Interval: 30 * time.Minute}); err != nil {
+		t.Fatal(err)
+	}
+	session := NewSession(table)
+	totalBefore := totalStacks(table)
+	if _, err := session.PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	if got := totalBefore - totalStacks(table); got != 18 {
+		t.Errorf("collected %v in the first period, want 18", got)
+	}
+	now = now.Add(10 * time.Minute)
+	if _, err := session.PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	if got := totalBefore - totalStacks(table); got != 18 {
+		t.Errorf("collected %v before the interval passed, want 18", got)
+	}
+	now = now.Add(20 * time.Minute)
+	if _, err := session.PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	if got := totalBefore - totalStacks(table); got != 36 {
+		t.Errorf("collected %v after the interval passed, want 36", got)
+	}
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+}
+
+func TestTable_SetRake(t *testing.T) {
+	tests := []struct {
+		name       string
+		rake       Rake
+		collection TimeCollection
+		wantErr    bool
+	}{
+		{
+			name:    "有効な設定",
+			rake:    Rake{BasisPoints: 500, Cap: 3, MinimumPot: 20},
+			wantErr: false,
+		},
+		{
+			name:    "100%を超える割合",
+			rake:    Rake{BasisPoints: 10001},
+			wantErr: true,
+		},
+		{
+			name:    "負の上限",
+			rake:    Rake{BasisPoints: 500, Cap: -1},
+			wantErr: true,
+		},
+		{
+			name:       "タイムチャージと併用",
+			rake:       Rake{BasisPoints: 500},
+			collection: TimeCollection{Amount: 5, Interval: time.Hour},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newTable("table", 3)
+			if err := table.SetTimeCollection(tt.collection); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.SetRake(tt.rake); (err != nil) != tt.wantErr {
+				t.Errorf("Table.SetRake() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// リバイの設定。スタックがスターティングスタック以下のプレイヤーが、ハンドの合間にチップを買い足せる
+type Rebuy struct {
+	// 所持金から支払い、賞金総額に加える額。通貨の最小単位で表す
+	Cost  int
+	Chips int
+	// リバイができるブラインドレベルの数。レベルがこの値より小さい間だけリバイできる。0ならリバイはできない
+	Levels int
+	// 1人あたりのリバイの回数の上限。0なら上限なし
+	Max int
+}
+
+// アドオンの設定。休憩のときに1人1回だけチップを買い足せる
+type AddOn struct {
+	Cost  int
+	Chips int
+	// アドオンができるブラインドレベル。休憩の直後のレベルを指定する
+	Level int
+}
+
+// リエントリーの設定。脱落したプレイヤーが、参加費を支払ってスターティングスタックでもう一度参加できる
+type ReEntry struct {
+	// リエントリーができるブラインドレベルの数。0ならリエントリーはできない
+	Levels int
+	// 最初の参加を含めた1人あたりの参加回数の上限。0なら上限なし
+	MaxEntries int
+}
+
+func validateRebuyOptions(config TournamentConfig) error {
+	rebuy, addOn, reEntry := config.Rebuy, config.AddOn, config.ReEntry
+	if rebuy.Cost < 0 || rebuy.Chips < 0 || rebuy.Levels < 0 || rebuy.Max < 0 {
+		return fmt.Errorf("rebuy options must not be negative")
+	}
+	if rebuy.Levels > 0 && rebuy.Chips == 0 {
+		return fmt.Errorf("rebuy must give chips")
+	}
+	if addOn.Cost < 0 || addOn.Chips < 0 || addOn.Level < 0 {
+		return fmt.Errorf("add-on options must not be negative")
+	}
+	if reEntry.Levels < 0 || reEntry.MaxEntries < 0 {
+		return fmt.Errorf("re-entry options must not be negative")
+	}
+	return nil
+}
+
+// リバイする。買い足したチップはtableの台帳に記録する
+func (b *tournamentBook) rebuy(table *Table, player *entity.Player, level int) error {
+	if err := b.requireRunning(); err != nil {
+		return err
+	}
+	rebuy := b.config.Rebuy
+	if rebuy.Levels == 0 {
+		return fmt.Errorf("rebuys are not allowed")
+	}
+	if level >= rebuy.Levels {
+		return fmt.Errorf("rebuy period is over")
+	}
+	if rebuy.Max > 0 && b.rebuys[player] >= rebuy.Max {
+		return fmt.Errorf("player has reached the rebuy limit of %d", rebuy.Max)
+	}
+	if player.Stack() > b.config.StartingStack {
+		return fmt.Errorf("stack must be at or below the starting stack to rebuy")
+	}
+	if err := b.purchase(table, player, rebuy.Cost, rebuy.Chips); err != nil {
+		return err
+	}
+	b.rebuys[player]++
+	return nil
+}
+
+// アドオンする。アドオンができるレベルの間に1人1回だけできる
+func (b *tournamentBook) addOn(table *Table, player *entity.Player, level int) error {
+	if err := b.requireRunning(); err != nil {
+		return err
+	}
+	addOn := b.config.AddOn
+	if addOn.Chips == 0 {
+		return fmt.Errorf("add-ons are not allowed")
+	}
+	if level != addOn.Level {
+		return fmt.Errorf("add-on is only available at level %d", addOn.Level)
+	}
+	if b.addOns[player] > 0 {
+		return fmt.Errorf("player has already taken the add-on")
+	}
+	if err := b.purchase(table, player, addOn.Cost, addOn.Chips); err != nil {
+		return err
+	}
+	b.addOns[player]++
+	return nil
+}
+
+// 所持金からcostを支払い、席にいるプレイヤーのスタックにchipsを加える
+func (b *tournamentBook) purchase(table *Table, player *entity.Player, cost, chips int) error {
+	if _, err := table.SeatOf(player); err != nil {
+		return err
+	}
+	if table.IsHandInProgress() {
+		return fmt.Errorf("cannot buy chips during a hand")
+	}
+	if err := b.collect(player, cost); err != nil {
+		return err
+	}
+	return table.AddChips(player, chips)
+}
+
+// 脱落したプレイヤーの順位を取り消し、参加費を支払ってスターティングスタックを受け取る
+// リエントリーで参加者が1人増えるので、後から脱落したプレイヤーの順位は1つずつ下がる
+// 席に着かせるのは呼び出し側で行う
+func (b *tournamentBook) reEnter(player *entity.Player, level int) error {
+	if err := b.requireRunning(); err != nil {
+		return err
+	}
+	reEntry := b.config.ReEntry
+	if reEntry.Levels == 0 {
+		return fmt.Errorf("re-entries are not allowed")
+	}
+	if level >= reEntry.Levels {
+		return fmt.Errorf("re-entry period is over")
+	}
+	index := -1
+	for i, standing := range b.finished {
+		if standing.Player == player {
+			index = i
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("player has not been eliminated")
+	}
+	for _, standing := range b.finished[index:] {
+		if !standing.Prize.IsZero() {
+			return fmt.Errorf("re-entry is closed once a player has finished in the money")
+		}
+	}
+	if reEntry.MaxEntries > 0 && b.entries[player] >= reEntry.MaxEntries {
+		return fmt.Errorf("player has reached the entry limit of %d", reEntry.MaxEntries)
+	}
+	if err := b.collect(player, b.config.BuyIn); err != nil {
+		return err
+	}
+	if err := player.AddChips(b.config.StartingStack); err != nil {
+		return err
+	}
+	b.entries[player]++
+	b.finished = append(b.finished[:index], b.finished[index+1:]...)
+	for i := index; i < len(b.finished); i++ {
+		b.finished[i].Position++
+	}
+	b.nextPosition++
+	return nil
+}
+
+func (b *tournamentBook) requireRunning() error {
+	if !b.started {
+		return fmt.Errorf("tournament has not started")
+	}
+	if b.IsFinished() {
+		return fmt.Errorf("tournament is over")
+	}
+	return nil
+}
+
+// 現在のブラインドレベルでリバイする
+func (t *Tournament) Rebuy(player *entity.Player) error {
+	return t.rebuy(t.table, player, t.level)
+}
+
+func (t *Tournament) AddOn(player *entity.Player) error {
+	return t.addOn(t.table, player, t.level)
+}
+
+// 脱落したプレイヤーを空いている席に着かせてもう一度参加させる
+func (t *Tournament) ReEnter(player *entity.Player) error {
+	seat := randomEmptySeat(t.table)
+	if seat < 0 {
+		return fmt.Errorf("no empty seat")
+	}
+	if err := t.reEnter(player, t.level); err != nil {
+		return err
+	}
+	return t.table.Join(seat, player)
+}
+
+func (d *TournamentDirector) Rebuy(player *entity.Player) error {
+	table, err := d.TableOf(player)
+	if err != nil {
+		return err
+	}
+	return d.rebuy(table, player, d.level)
+}
+
+func (d *TournamentDirector) AddOn(player *entity.Player) error {
+	table, err := d.TableOf(player)
+	if err != nil {
+		return err
+	}
+	return d.addOn(table, player, d.level)
+}
+
+// 脱落したプレイヤーを最も人数の少ないテーブルに着かせてもう一度参加させる
+// どのテーブルにも空席がなければ新しいテーブルを用意し、人数の差が1人以内になるようにプレイヤーを移動させる
+func (d *TournamentDirector) ReEnter(player *entity.Player) error {
+	if !d.started {
+		return fmt.Errorf("tournament has not started")
+	}
+	if err := d.reEnter(player, d.level); err != nil {
+		return err
+	}
+	table := d.smallestTable()
+	if randomEmptySeat(table) < 0 {
+		var err error
+		if table, err = d.openTable(); err != nil {
+			return err
+		}
+	}
+	if err := table.Join(randomEmptySeat(table), player); err != nil {
+		return err
+	}
+	return d.balanceTables()
+}
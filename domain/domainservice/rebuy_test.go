@@ -0,0 +1,334 @@
+package domainservice
+
+import (
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func TestNewTournament_RebuyOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TournamentConfig
+	}{
+		{
+			name:   "リバイの額が負",
+			config: TournamentConfig{Rebuy: Rebuy{Cost: -1, Chips: 1000, Levels: 1}},
+		},
+		{
+			name:   "チップを受け取らないリバイ",
+			config: TournamentConfig{Rebuy: Rebuy{Cost: 100, Levels: 1}},
+		},
+		{
+			name:   "アドオンのレベルが負",
+			config: TournamentConfig{AddOn: AddOn{Cost: 100, Chips: 1000, Level: -1}},
+		},
+		{
+			name:   "リエントリーの回数が負",
+			config: TournamentConfig{ReEntry: ReEntry{Levels: 1, MaxEntries: -1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.BuyIn, config.StartingStack, config.Schedule, config.Payouts = 100, 1000, testSchedule, []int{10000}
+			if _, err := NewTournament(nil, config); err == nil {
+				t.Errorf("NewTournament() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestTournament_Rebuy(t *testing.T) {
+	config := TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{10000},
+		Rebuy:         Rebuy{Cost: 100, Chips: 1000, Levels: 1, Max: 2},
+	}
+	tournament, players := newTestTournament(t, 3, config)
+	if err := tournament.Rebuy(players[0]); err == nil {
+		t.Errorf("Tournament.Rebuy() before the start error = nil, want error")
+	}
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tournament.Rebuy(players[0]); err != nil {
+		t.Fatal(err)
+	}
+	if players[0].Stack() != 2000 || players[0].Money().Amount() != 800 {
+		t.Errorf("stack, money = %v, %v, want 2000, 800", players[0].Stack(), players[0].Money())
+	}
+	if tournament.PrizePool().Amount() != 400 {
+		t.Errorf("Tournament.PrizePool() = %v, want 400", tournament.PrizePool())
+	}
+	if err := tournament.Rebuy(players[0]); err == nil {
+		t.Errorf("Tournament.Rebuy() above the starting stack error = nil, want error")
+	}
+	// 買い足したチップも台帳に記録され、次のハンドを問題なく行える
+	if _, err := tournament.PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tournament.Table().CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+	tournament.level = 1
+	if err := tournament.Rebuy(players[1]); err == nil {
+		t.Errorf("Tournament.Rebuy() after the rebuy period error = nil, want error")
+	}
+}
+
+func TestTournament_Rebuy_Limit(t *testing.T) {
+	config := TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{10000},
+		Rebuy:         Rebuy{Cost: 100, Chips: 100, Levels: 1, Max: 2},
+	}
+	tournament, players := newTestTournament(t, 3, config)
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// リバイ後もスターティングスタック以下なので、上限まではリバイできる
+	players[0].PostAnte(500)
+	tournament.Table().record(LedgerAnte, players[0], 0)
+	for i := 0; i < 2; i++ {
+		if err := tournament.Rebuy(players[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tournament.Rebuy(players[0]); err == nil {
+		t.Errorf("Tournament.Rebuy() over the limit error = nil, want error")
+	}
+}
+
+func TestTournament_AddOn(t *testing.T) {
+	config := TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{10000},
+		AddOn:         AddOn{Cost: 50, Chips: 1500, Level: 1},
+	}
+	tournament, players := newTestTournament(t, 3, config)
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tournament.AddOn(players[0]); err == nil {
+		t.Errorf("Tournament.AddOn() before the break error = nil, want error")
+	}
+	tournament.level = 1
+	if err := tournament.AddOn(players[0]); err != nil {
+		t.Fatal(err)
+	}
+	if players[0].Stack() != 2500 || players[0].Money().Amount() != 850 || tournament.PrizePool().Amount() != 350 {
+		t.Errorf("stack, money, prize pool = %v, %v, %v, want 2500, 850, 350", players[0].Stack(), players[0].Money(), tournament.PrizePool())
+	}
+	if err := tournament.AddOn(players[0]); err == nil {
+		t.Errorf("second Tournament.AddOn() error = nil, want error")
+	}
+	if err := tournament.Rebuy(players[1]); err == nil {
+		t.Errorf("Tournament.Rebuy() without rebuy options error = nil, want error")
+	}
+}
+
+// 誰かが脱落するまでハンドを行い、脱落したプレイヤーを返す
+func playUntilElimination(t *testing.T, tournament *Tournament) *entity.Player {
+	t.Helper()
+	for len(tournament.finished) == 0 {
+		if _, err := tournament.PlayHand(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tournament.finished[0].Player
+}
+
+// playersだけがオールインし、他のプレイヤーはフォールドするか、フォールドできなければチェックする
+type selectiveAllInDecider struct {
+	players []*entity.Player
+}
+
+func (d selectiveAllInDecider) DecideAction(t *Table, player *entity.Player) Action {
+	if containsPlayer(d.players, player) {
+		return allInDecider{}.DecideAction(t, player)
+	}
+	if containsAction(t.PermittedActions(player), ActionFold) {
+		return Action{Type: ActionFold}
+	}
+	return Action{Type: ActionCheck}
+}
+
+func (selectiveAllInDecider) DecideDiscards(t *Table, player *entity.Player) []*valueobject.Card {
+	return nil
+}
+
+func TestTournament_ReEnter(t *testing.T) {
+	config := TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{10000},
+		ReEntry:       ReEntry{Levels: 3, MaxEntries: 2},
+	}
+	tournament, players := newTestTournament(t, 3, config)
+	// 2人だけがオールインし、最初に脱落するのを1人にする
+	tournament.SetDecider(selectiveAllInDecider{players: players[:2]})
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tournament.ReEnter(players[0]); err == nil {
+		t.Errorf("Tournament.ReEnter() for a player still in error = nil, want error")
+	}
+	busted := playUntilElimination(t, tournament)
+	if err := tournament.ReEnter(busted); err != nil {
+		t.Fatal(err)
+	}
+	if busted.Stack() != 1000 || busted.Money().Amount() != 800 || tournament.PrizePool().Amount() != 400 {
+		t.Errorf("stack, money, prize pool = %v, %v, %v, want 1000, 800, 400", busted.Stack(), busted.Money(), tournament.PrizePool())
+	}
+	if _, err := tournament.Table().SeatOf(busted); err != nil {
+		t.Errorf("re-entered player is not seated: %v", err)
+	}
+	tournament.SetDecider(allInDecider{})
+	standings, err := tournament.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(standings) != len(players) {
+		t.Fatalf("len(standings) = %v, want %v", len(standings), len(players))
+	}
+	entries := 0
+	for _, standing := range standings {
+		entries += standing.Entries
+		if standing.Player == busted && standing.Entries != 2 {
+			t.Errorf("Standing.Entries = %v, want 2", standing.Entries)
+		}
+	}
+	if entries != 4 {
+		t.Errorf("total entries = %v, want 4", entries)
+	}
+	if standings[0].Prize.Amount() != 400 {
+		t.Errorf("winner prize = %v, want 400", standings[0].Prize)
+	}
+}
+
+func TestTournament_ReEnter_Limit(t *testing.T) {
+	config := TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{10000},
+		ReEntry:       ReEntry{Levels: 3, MaxEntries: 1},
+	}
+	tournament, players := newTestTournament(t, 3, config)
+	tournament.SetDecider(selectiveAllInDecider{players: players[:2]})
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	busted := playUntilElimination(t, tournament)
+	if err := tournament.ReEnter(busted); err == nil {
+		t.Errorf("Tournament.ReEnter() over the entry limit error = nil, want error")
+	}
+}
+
+func TestTournamentDirector_RebuyAndReEnter(t *testing.T) {
+	config := TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{10000},
+		Rebuy:         Rebuy{Cost: 100, Chips: 1000, Levels: 2},
+		ReEntry:       ReEntry{Levels: 2},
+	}
+	director, err := NewTournamentDirector(config, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	players := []*entity.Player{}
+	for i := 0; i < 5; i++ {
+		player := newTestPlayer(t, "player", 1000)
+		if err := director.Register(player); err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, player)
+	}
+	director.SetDecider(allInDecider{})
+	if err := director.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := director.Rebuy(players[0]); err != nil {
+		t.Fatal(err)
+	}
+	if players[0].Stack() != 2000 || director.PrizePool().Amount() != 600 {
+		t.Errorf("stack, prize pool = %v, %v, want 2000, 600", players[0].Stack(), director.PrizePool())
+	}
+	for len(director.finished) == 0 {
+		if err := director.PlayRound(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if director.Level() >= 2 {
+		t.Fatalf("re-entry period ended before the first elimination")
+	}
+	busted := director.finished[0].Player
+	if err := director.ReEnter(busted); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := director.TableOf(busted); err != nil {
+		t.Errorf("re-entered player is not seated: %v", err)
+	}
+	standings, err := director.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(standings) != len(players) {
+		t.Errorf("len(standings) = %v, want %v", len(standings), len(players))
+	}
+}
+
+func TestTournamentBook_ReEnter_Positions(t *testing.T) {
+	book, err := newTournamentBook(TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{10000},
+		ReEntry:       ReEntry{Levels: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b, c, d := newTestPlayer(t, "a", 1000), newTestPlayer(t, "b", 1000), newTestPlayer(t, "c", 1000), newTestPlayer(t, "d", 1000)
+	for _, player := range []*entity.Player{a, b, c, d} {
+		if err := book.register(player, 4); err != nil {
+			t.Fatal(err)
+		}
+	}
+	book.started = true
+	eliminate := func(player *entity.Player, remaining []*entity.Player, handNumber int) {
+		t.Helper()
+		if err := book.recordEliminations(map[*entity.Player]int{player: 0}, remaining, handNumber); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// aとbが脱落した後にaがリエントリーし、その後にcとdが脱落する
+	eliminate(a, []*entity.Player{b, c, d}, 1)
+	eliminate(b, []*entity.Player{c, d}, 2)
+	if err := book.reEnter(a, 0); err != nil {
+		t.Fatal(err)
+	}
+	eliminate(c, []*entity.Player{a, d}, 3)
+	eliminate(d, []*entity.Player{a}, 4)
+	want := []*entity.Player{a, d, c, b}
+	standings := book.Standings()
+	if len(standings) != len(want) {
+		t.Fatalf("len(Standings()) = %v, want %v", len(standings), len(want))
+	}
+	for i, standing := range standings {
+		if standing.Position != i+1 || standing.Player != want[i] {
+			t.Errorf("Standings()[%d] = %v %v, want %v %v", i, standing.Position, standing.Player.Name(), i+1, want[i].Name())
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ドローを行う回数の上限
+const maxRuns = 3
+
+// オールインの後にドローを何回行うか。同意がなければ1
+func (t *Table) Runs() int {
+	return t.runs
+}
+
+// ドローを複数回行う同意ができる状態かどうか
+// ドロー前のベッティングで全員がオールインし (コールした1人だけはチップが残っていてもよい)、まだ誰も交換していない場合に限る
+func (t *Table) CanRunMultipleTimes() bool {
+	if t.phase != PhaseDraw || t.drawer != t.nextDrawer(0) {
+		return false
+	}
+	remaining := t.RemainingPlayers()
+	return len(remaining) >= 2 && t.countCanAct() <= 1
+}
+
+// ドローをtimes回行うことに同意する。残っている全員が同じ回数に同意した時点で、その回数だけドローを行う
+func (t *Table) AgreeToRunMultipleTimes(player *entity.Player, times int) error {
+	if !t.CanRunMultipleTimes() {
+		return fmt.Errorf("cannot run multiple times now")
+	}
+	if times < 2 || times > maxRuns {
+		return fmt.Errorf("times must be between 2 and %d", maxRuns)
+	}
+	if !containsPlayer(t.RemainingPlayers(), player) {
+		return fmt.Errorf("player is not in the hand")
+	}
+	t.runAgreements[player] = times
+	for _, remaining := range t.RemainingPlayers() {
+		if t.runAgreements[remaining] != times {
+			return nil
+		}
+	}
+	t.runs = times
+	return nil
+}
+
+// run回目 (0から数える) のドローでのプレイヤーの手札。ドローを複数回行っていなければ現在の手札
+func (t *Table) RunHand(player *entity.Player, run int) ([]*valueobject.Card, error) {
+	if run < 0 || run >= t.runs {
+		return nil, fmt.Errorf("run %d does not exist", run)
+	}
+	if hands, ok := t.runHands[player]; ok {
+		return hands[run], nil
+	}
+	return player.Cards(), nil
+}
+
+// 捨てた後に残ったカードに、ドローの回数分だけ山札から引いたカードを加えた手札を記録する
+// 1回目の手札がプレイヤーの手札になる
+func (t *Table) drawRuns(player *entity.Player, discards []*valueobject.Card) error {
+	if err := player.Discard(discards); err != nil {
+		return err
+	}
+	cards, err := t.takeCards(len(discards) * t.runs)
+	if err != nil {
+		for _, card := range discards {
+			player.DrawCard(card)
+		}
+		return err
+	}
+	t.muck = append(t.muck, discards...)
+	kept := player.Cards()
+	hands := make([][]*valueobject.Card, t.runs)
+	for run := range hands {
+		hands[run] = append([]*valueobject.Card{}, kept...)
+		hands[run] = append(hands[run], cards[run*len(discards):(run+1)*len(discards)]...)
+	}
+	for _, card := range hands[0][len(kept):] {
+		player.DrawCard(card)
+	}
+	t.runHands[player] = hands
+	return nil
+}
+
+// ドローを複数回行ったハンドで、各ポットをドローの回数で等分し、それぞれの手札で勝者を判定して支払う
+// 割り切れないチップは先のドローから、同じドローで引き分けた場合は先のプレイヤーから1枚ずつ配る
+// ドローごとの勝者を返す
+func (t *Table) DistributeRuns() ([][]*entity.Player, error) {
+	if err := t.requirePhase("distribute chips", PhasePayout); err != nil {
+		return nil, err
+	}
+	if t.runs < 2 {
+		return nil, fmt.Errorf("hand was not run multiple times")
+	}
+	t.CollectBets()
+	pots := t.Pots()
+	t.takeRake(pots)
+	awards := make([][][]*entity.Player, t.runs)
+	runWinners := make([][]*entity.Player, t.runs)
+	for run := range awards {
+		awards[run] = make([][]*entity.Player, len(pots))
+		err := t.withRunHands(run, func() error {
+			for i, pot := range pots {
+				winners, err := t.judgeWinner(pot.Eligible)
+				if err != nil {
+					return err
+				}
+				awards[run][i] = winners
+				for _, winner := range winners {
+					if !containsPlayer(runWinners[run], winner) {
+						runWinners[run] = append(runWinners[run], winner)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	t.pot = 0
+	potWinners := []*entity.Player{}
+	for i, pot := range pots {
+		for run := range awards {
+			amount := splitChips(pot.Amount, t.runs, run)
+			for j, winner := range awards[run][i] {
+				winner.Win(t.record(LedgerAward, winner, splitChips(amount, len(awards[run][i]), j)))
+				if !containsPlayer(potWinners, winner) {
+					potWinners = append(potWinners, winner)
+				}
+			}
+		}
+	}
+	t.phase = PhaseComplete
+	if err := t.afterPayout(potWinners); err != nil {
+		return nil, err
+	}
+	return runWinners, nil
+}
+
+// chipsをn等分したときのi番目の取り分。割り切れない分は先頭から1枚ずつ配る
+func splitChips(chips, n, i int) int {
+	share := chips / n
+	if i < chips%n {
+		share++
+	}
+	return share
+}
+
+// 残っているプレイヤーの手札を一時的にrun回目のドローの手札に替えてfを実行する
+func (t *Table) withRunHands(run int, f func() error) error {
+	original := map[*entity.Player][]*valueobject.Card{}
+	for player, hands := range t.runHands {
+		original[player] = player.ReturnCards()
+		for _, card := range hands[run] {
+			player.DrawCard(card)
+		}
+	}
+	defer func() {
+		for player, cards := range original {
+			player.ReturnCards()
+			for _, card := range cards {
+				player.DrawCard(card)
+			}
+		}
+	}()
+	return f()
+}
@@ -0,0 +1,355 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func TestTable_AgreeToRunMultipleTimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		times    []int
+		wantRuns int
+		wantErr  bool
+	}{
+		{
+			name:     "全員が2回に同意すると2回ドローする",
+			times:    []int{2, 2},
+			wantRuns: 2,
+		},
+		{
+			name:     "全員が3回に同意すると3回ドローする",
+			times:    []int{3, 3},
+			wantRuns: 3,
+		},
+		{
+			name:     "回数が食い違えば1回のまま",
+			times:    []int{2, 3},
+			wantRuns: 1,
+		},
+		{
+			name:     "1人しか同意していなければ1回のまま",
+			times:    []int{2},
+			wantRuns: 1,
+		},
+		{
+			name:     "上限を超える回数には同意できない",
+			times:    []int{4},
+			wantRuns: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "1回には同意できない",
+			times:    []int{1},
+			wantRuns: 1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newAllInTestTable(t, []int{50, 100})
+			playAllInUntil(t, table, PhaseDraw)
+			if !table.CanRunMultipleTimes() {
+				t.Fatalf("Table.CanRunMultipleTimes() = false, want true")
+			}
+			var err error
+			for i, times := range tt.times {
+				if err = table.AgreeToRunMultipleTimes(players[i], times); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.AgreeToRunMultipleTimes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := table.Runs(); got != tt.wantRuns {
+				t.Errorf("Table.Runs() = %v, want %v", got, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestTable_CanRunMultipleTimes(t *testing.T) {
+	table, players := newAllInTestTable(t, []int{50, 100})
+	playAllInUntil(t, table, PhaseDraw)
+	if err := table.AgreeToRunMultipleTimes(newTestPlayer(t, "stranger", 100), 2); err == nil {
+		t.Errorf("Table.AgreeToRunMultipleTimes() error = nil, want error")
+	}
+	if err := table.Draw(table.Drawer(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if table.CanRunMultipleTimes() {
+		t.Errorf("Table.CanRunMultipleTimes() = true after a draw, want false")
+	}
+	if err := table.AgreeToRunMultipleTimes(players[0], 2); err == nil {
+		t.Errorf("Table.AgreeToRunMultipleTimes() error = nil after a draw, want error")
+	}
+
+	// チップの残っているプレイヤーが2人以上いれば、まだベットが続きうるので同意できない
+	table, _ = newAllInTestTable(t, []int{100, 100, 100})
+	for table.Phase() == PhaseBetting {
+		if err := table.Act(table.Actor(), PassiveDecider{}.DecideAction(table, table.Actor())); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if table.CanRunMultipleTimes() {
+		t.Errorf("Table.CanRunMultipleTimes() = true without all-in, want false")
+	}
+}
+
+func TestTable_Draw_MultipleRuns(t *testing.T) {
+	table, players := newAllInTestTable(t, []int{50, 100})
+	playAllInUntil(t, table, PhaseDraw)
+	for _, player := range players {
+		if err := table.AgreeToRunMultipleTimes(player, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	drawer := table.Drawer()
+	kept := drawer.Cards()[2:]
+	deck := len(table.deck)
+	if err := table.Draw(drawer, drawer.Cards()[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if got := deck - len(table.deck); got != 6 {
+		t.Errorf("cards drawn from deck = %v, want 6", got)
+	}
+	seen := map[*valueobject.Card]bool{}
+	for run := 0; run < 3; run++ {
+		hand, err := table.RunHand(drawer, run)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(hand[:3], kept) {
+			t.Errorf("RunHand(%d) kept cards = %v, want %v", run, hand[:3], kept)
+		}
+		for _, card := range hand[3:] {
+			if seen[card] {
+				t.Errorf("RunHand(%d) reuses card %v", run, card)
+			}
+			seen[card] = true
+		}
+		if run == 0 && !reflect.DeepEqual(hand, drawer.Cards()) {
+			t.Errorf("RunHand(0) = %v, want the player's cards %v", hand, drawer.Cards())
+		}
+	}
+	if _, err := table.RunHand(drawer, 3); err == nil {
+		t.Errorf("Table.RunHand() error = nil, want error")
+	}
+
+	// 交換しなければどのドローでも同じ手札になる
+	stander := table.Drawer()
+	if err := table.Draw(stander, nil); err != nil {
+		t.Fatal(err)
+	}
+	for run := 0; run < 3; run++ {
+		hand, err := table.RunHand(stander, run)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(hand, stander.Cards()) {
+			t.Errorf("RunHand(%d) = %v, want %v", run, hand, stander.Cards())
+		}
+	}
+}
+
+func TestTable_DistributeRuns(t *testing.T) {
+	tests := []struct {
+		name           string
+		stacks         []int
+		runs           int
+		hands          [][]string
+		wantStacks     []int
+		wantRunWinners [][]int
+	}{
+		{
+			name:   "2回のドローを1回ずつ勝てばポットを半分ずつ分ける",
+			stacks: []int{50, 50},
+			runs:   2,
+			hands: [][]string{
+				{"AsKsQsJsTs", "2c2d7h8h9d"},
+				{"2c2d7h8h9d", "AsKsQsJsTs"},
+			},
+			wantStacks:     []int{50, 50},
+			wantRunWinners: [][]int{{0}, {1}},
+		},
+		{
+			name:   "割り切れないチップは先のドローに配る",
+			stacks: []int{50, 50},
+			runs:   3,
+			hands: [][]string{
+				{"2c2d7h8h9d", "AsKsQsJsTs"},
+				{"AsKsQsJsTs", "2c2d7h8h9d"},
+				{"AsKsQsJsTs", "2c2d7h8h9d"},
+			},
+			wantStacks:     []int{66, 34},
+			wantRunWinners: [][]int{{1}, {0}, {0}},
+		},
+		{
+			name:   "サイドポットもドローごとに分ける",
+			stacks: []int{50, 100, 150},
+			runs:   2,
+			hands: [][]string{
+				{"AsKsQsJsTs", "2c2d7h8h9d", "3c5d7c9hJd"},
+				{"2c2d7h8h9d", "3c5d7c9hJd", "AsKsQsJsTs"},
+			},
+			wantStacks:     []int{75, 50, 175},
+			wantRunWinners: [][]int{{0, 1}, {2}},
+		},
+		{
+			name:   "同じドローで引き分ければ、そのドローの取り分をさらに分ける",
+			stacks: []int{50, 50, 50},
+			runs:   2,
+			hands: [][]string{
+				{"AsKsQsJs9d", "AhKhQhJh9c", "2c3d5h7s9c"},
+				{"AsKsQsJs9d", "AhKhQhJh9c", "2c2d5h7s9c"},
+			},
+			// 端数はボタンの左に近いプレイヤーに配る
+			wantStacks:     []int{37, 38, 75},
+			wantRunWinners: [][]int{{0, 1}, {2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newAllInTestTable(t, tt.stacks)
+			playAllInUntil(t, table, PhaseDraw)
+			for _, player := range players {
+				if err := table.AgreeToRunMultipleTimes(player, tt.runs); err != nil {
+					t.Fatal(err)
+				}
+			}
+			playAllInUntil(t, table, PhaseShowdown)
+			for i, player := range players {
+				hands := make([][]*valueobject.Card, tt.runs)
+				for run := range hands {
+					hands[run] = mustParseCards(t, tt.hands[run][i])
+				}
+				table.runHands[player] = hands
+				player.ReturnCards()
+				for _, card := range hands[0] {
+					player.DrawCard(card)
+				}
+			}
+			if _, err := table.JudgeWinner(); err != nil {
+				t.Fatal(err)
+			}
+			if err := table.DistributeChips(players); err == nil {
+				t.Errorf("Table.DistributeChips() error = nil, want error")
+			}
+			runWinners, err := table.DistributeRuns()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int{}
+			for _, player := range players {
+				got = append(got, player.Stack())
+			}
+			if !reflect.DeepEqual(got, tt.wantStacks) {
+				t.Errorf("stacks = %v, want %v", got, tt.wantStacks)
+			}
+			wantRunWinners := [][]*entity.Player{}
+			for _, indexes := range tt.wantRunWinners {
+				winners := []*entity.Player{}
+				for _, i := range indexes {
+					winners = append(winners, players[i])
+				}
+				wantRunWinners = append(wantRunWinners, winners)
+			}
+			if !sameRunWinners(runWinners, wantRunWinners) {
+				t.Errorf("Table.DistributeRuns() = %v, want %v", runWinners, wantRunWinners)
+			}
+			for i, player := range players {
+				if !sameCards(player.Cards(), mustParseCards(t, tt.hands[0][i])) {
+					t.Errorf("player %d cards = %v, want the first run's hand", i, player.Cards())
+				}
+			}
+			if err := table.CheckConservation(); err != nil {
+				t.Errorf("Table.CheckConservation() error = %v", err)
+			}
+			if err := table.EndHand(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// ドローごとの勝者を、並び順を問わずに比べる
+func sameRunWinners(got, want [][]*entity.Player) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for run := range got {
+		if len(got[run]) != len(want[run]) {
+			return false
+		}
+		for _, winner := range want[run] {
+			if !containsPlayer(got[run], winner) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// 並び順を問わずに同じカードかどうか
+func sameCards(got, want []*valueobject.Card) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for _, card := range want {
+		found := false
+		for _, g := range got {
+			if g.String() == card.String() {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ドローを複数回行える場面では、常にtimes回を選ぶプレイヤー
+type runsDecider struct {
+	allInDecider
+	times int
+}
+
+func (d runsDecider) DecideRuns(t *Table, player *entity.Player) int {
+	return d.times
+}
+
+func TestSession_RunMultipleTimes(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		table, err := NewTableWithSeats("table", 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+			t.Fatal(err)
+		}
+		for seat, stack := range []int{50, 100, 150} {
+			if err := table.Join(seat, newPlayerWithStack(t, "player", stack)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		session := NewSession(table)
+		session.SetDecider(runsDecider{times: 2})
+		result, err := session.PlayHand()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.RunWinners) != 2 {
+			t.Errorf("len(HandResult.RunWinners) = %v, want 2", len(result.RunWinners))
+		}
+		if got := totalStacks(table); got != 300 {
+			t.Errorf("total stacks = %v, want 300", got)
+		}
+		if err := table.CheckConservation(); err != nil {
+			t.Errorf("Table.CheckConservation() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+const (
+	minSeats = 2
+	maxSeats = 10
+)
+
+type Seat struct {
+	player *entity.Player
+	// 途中から着席し、ビッグブラインドが回ってくるのを待っている
+	waitingForBigBlind bool
+	// シットアウト中に支払わなかったブラインド。復帰するときに支払う
+	missedSmallBlind bool
+	missedBigBlind   bool
+	// ハンド中に受け付け、次のハンドから反映する操作
+	pendingLeave  bool
+	pendingSitOut bool
+	pendingSitIn  bool
+}
+
+func (s *Seat) Player() *entity.Player {
+	return s.player
+}
+
+func (s *Seat) IsEmpty() bool {
+	return s.player == nil
+}
+
+func (s *Seat) IsWaitingForBigBlind() bool {
+	return s.waitingForBigBlind
+}
+
+func (s *Seat) MissedSmallBlind() bool {
+	return s.missedSmallBlind
+}
+
+func (s *Seat) MissedBigBlind() bool {
+	return s.missedBigBlind
+}
+
+// プレイヤーが座っている席番号を返す
+func (t *Table) SeatOf(player *entity.Player) (int, error) {
+	for i, seat := range t.seats {
+		if seat.player == player {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("player is not seated")
+}
+
+// 空席にプレイヤーを座らせる
+// 最初のハンドが始まった後に着席したプレイヤーは、ビッグブラインドが回ってくるまで参加しない
+func (t *Table) Join(seatNumber int, player *entity.Player) error {
+	if seatNumber < 0 || seatNumber >= len(t.seats) {
+		return fmt.Errorf("seat %d does not exist", seatNumber)
+	}
+	if !t.seats[seatNumber].IsEmpty() {
+		return fmt.Errorf("seat %d is not empty", seatNumber)
+	}
+	if _, err := t.SeatOf(player); err == nil {
+		return fmt.Errorf("player is already seated")
+	}
+	if (len(t.seatedPlayers())+1)*cardsPerHand > t.deckCapacity() {
+		return fmt.Errorf("not enough cards in deck to deal to %d players", len(t.seatedPlayers())+1)
+	}
+	t.seats[seatNumber] = &Seat{
+		player:             player,
+		waitingForBigBlind: t.button >= 0,
+	}
+	player.SitIn()
+	t.record(LedgerBuyIn, player, player.Stack())
+	return nil
+}
+
+// ハンドの合間に、席にいるプレイヤーのスタックにチップを加える。台帳にはバイインとして記録する
+func (t *Table) AddChips(player *entity.Player, chips int) error {
+	if t.IsHandInProgress() {
+		return fmt.Errorf("cannot add chips during a hand")
+	}
+	if _, err := t.SeatOf(player); err != nil {
+		return err
+	}
+	if err := player.AddChips(chips); err != nil {
+		return err
+	}
+	t.record(LedgerBuyIn, player, chips)
+	return nil
+}
+
+// 席を立つ。ハンド中であればハンドの終了後に席を立つ
+func (t *Table) Leave(player *entity.Player) error {
+	seatNumber, err := t.SeatOf(player)
+	if err != nil {
+		return err
+	}
+	if t.isInCurrentHand(player) {
+		t.seats[seatNumber].pendingLeave = true
+		return nil
+	}
+	t.removePlayer(seatNumber)
+	return nil
+}
+
+// シットアウトする。ハンド中であれば次のハンドから反映する
+func (t *Table) SitOut(player *entity.Player) error {
+	seatNumber, err := t.SeatOf(player)
+	if err != nil {
+		return err
+	}
+	seat := t.seats[seatNumber]
+	if t.isInCurrentHand(player) {
+		seat.pendingSitOut = true
+		seat.pendingSitIn = false
+		return nil
+	}
+	player.SitOut()
+	return nil
+}
+
+// シットアウトから復帰する。ハンド中であれば次のハンドから反映する
+func (t *Table) SitIn(player *entity.Player) error {
+	seatNumber, err := t.SeatOf(player)
+	if err != nil {
+		return err
+	}
+	seat := t.seats[seatNumber]
+	if t.IsHandInProgress() {
+		seat.pendingSitIn = true
+		seat.pendingSitOut = false
+		return nil
+	}
+	player.SitIn()
+	return nil
+}
+
+// 席を空け、プレイヤーのスタックをキャッシュアウトとして記録する
+func (t *Table) removePlayer(seatNumber int) {
+	player := t.seats[seatNumber].player
+	t.record(LedgerCashOut, player, player.Stack())
+	t.seats[seatNumber] = &Seat{}
+}
+
+// 席に着いているすべてのプレイヤー。シットアウト中のプレイヤーを含む
+func (t *Table) seatedPlayers() []*entity.Player {
+	players := []*entity.Player{}
+	for _, seat := range t.seats {
+		if !seat.IsEmpty() {
+			players = append(players, seat.player)
+		}
+	}
+	return players
+}
+
+func (t *Table) isInCurrentHand(player *entity.Player) bool {
+	return t.IsHandInProgress() && t.isSeated(player)
+}
+
+// ハンド中に受け付けた操作を反映する
+func (t *Table) applyPendingSeatChanges() {
+	for i, seat := range t.seats {
+		switch {
+		case seat.pendingLeave:
+			t.removePlayer(i)
+		case seat.pendingSitOut:
+			seat.player.SitOut()
+		case seat.pendingSitIn:
+			seat.player.SitIn()
+		}
+		seat.pendingSitOut = false
+		seat.pendingSitIn = false
+	}
+}
+
+// ハンドを始める。カードを集めてシャッフルし直し、ボタンを進めて参加するプレイヤーを決める
+func (t *Table) StartHand() error {
+	if err := t.requirePhase("start hand", PhaseWaiting); err != nil {
+		return err
+	}
+	t.applyPendingSeatChanges()
+	t.collectCards()
+	t.handNumber++
+	t.handRake = 0
+	t.collectTime()
+	if err := t.MoveButton(); err != nil {
+		return err
+	}
+	t.seats[t.bigBlindSeat].waitingForBigBlind = false
+	t.players = []*entity.Player{}
+	for i := 1; i <= len(t.seats); i++ {
+		seat := (t.button + i) % len(t.seats)
+		if t.isLive(seat) {
+			t.players = append(t.players, t.seats[seat].player)
+		}
+	}
+	t.folded = map[*entity.Player]bool{}
+	t.contributions = map[*entity.Player]int{}
+	t.deadMoney = map[*entity.Player]int{}
+	t.bettingRound = 0
+	t.runs = 1
+	t.runAgreements = map[*entity.Player]int{}
+	t.runHands = map[*entity.Player][][]*valueobject.Card{}
+	t.straddler = nil
+	t.showdownOrder = nil
+	t.shown = map[*entity.Player]bool{}
+	t.shownCards = map[*entity.Player][]*valueobject.Card{}
+	t.reveals = nil
+	t.phase = PhasePosting
+	return nil
+}
+
+// 前のハンドのカードを集めてデッキをシャッフルし直す
+func (t *Table) collectCards() {
+	for _, seat := range t.seats {
+		if !seat.IsEmpty() {
+			seat.player.ReturnCards()
+		}
+	}
+	t.deck = shuffleDeck(createDeck())
+	t.decks = 1
+	t.muck = nil
+}
+
+// ハンドを終える。ハンド中に受け付けた操作はここで反映する
+func (t *Table) EndHand() error {
+	if err := t.requirePhase("end hand", PhaseComplete); err != nil {
+		return err
+	}
+	if err := t.CheckConservation(); err != nil {
+		return err
+	}
+	t.phase = PhaseWaiting
+	t.applyPendingSeatChanges()
+	return nil
+}
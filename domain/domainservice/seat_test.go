@@ -0,0 +1,266 @@
+package domainservice
+
+import (
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// numberOfSeats席のテーブルのseatNumbersの席にプレイヤーを座らせる
+func newSeatTestTable(t *testing.T, numberOfSeats int, seatNumbers []int) (*Table, map[int]*entity.Player) {
+	t.Helper()
+	table, err := NewTableWithSeats("table", numberOfSeats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	players := map[int]*entity.Player{}
+	for _, seatNumber := range seatNumbers {
+		player := newPlayerWithStack(t, "player", 100)
+		if err := table.Join(seatNumber, player); err != nil {
+			t.Fatal(err)
+		}
+		players[seatNumber] = player
+	}
+	return table, players
+}
+
+// 現在のハンドを全員チェックかコールで最後まで進めて終える
+func finishHand(t *testing.T, table *Table) {
+	t.Helper()
+	if table.Phase() == PhasePosting {
+		if err := table.PostBlinds(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if table.Phase() == PhaseDealing {
+		if err := table.DealCards(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := NewSession(table).playUntilComplete(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.EndHand(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewTableWithSeats(t *testing.T) {
+	tests := []struct {
+		name          string
+		numberOfSeats int
+		wantErr       bool
+	}{
+		{
+			name:          "ヘッズアップ",
+			numberOfSeats: 2,
+			wantErr:       false,
+		},
+		{
+			name:          "10席",
+			numberOfSeats: 10,
+			wantErr:       false,
+		},
+		{
+			name:          "1席",
+			numberOfSeats: 1,
+			wantErr:       true,
+		},
+		{
+			name:          "11席",
+			numberOfSeats: 11,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTableWithSeats("table", tt.numberOfSeats)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTableWithSeats() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && len(got.Seats()) != tt.numberOfSeats {
+				t.Errorf("len(Seats()) = %v, want %v", len(got.Seats()), tt.numberOfSeats)
+			}
+		})
+	}
+}
+
+func TestTable_Join(t *testing.T) {
+	table, players := newSeatTestTable(t, 6, []int{0})
+	tests := []struct {
+		name       string
+		seatNumber int
+		player     *entity.Player
+		wantErr    bool
+	}{
+		{
+			name:       "空席に座る",
+			seatNumber: 1,
+			player:     newTestPlayer(t, "new", 0),
+			wantErr:    false,
+		},
+		{
+			name:       "存在しない席",
+			seatNumber: 6,
+			player:     newTestPlayer(t, "new", 0),
+			wantErr:    true,
+		},
+		{
+			name:       "埋まっている席",
+			seatNumber: 0,
+			player:     newTestPlayer(t, "new", 0),
+			wantErr:    true,
+		},
+		{
+			name:       "既に座っているプレイヤー",
+			seatNumber: 2,
+			player:     players[0],
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := table.Join(tt.seatNumber, tt.player); (err != nil) != tt.wantErr {
+				t.Errorf("Table.Join() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable_AddChips(t *testing.T) {
+	table, players := newSeatTestTable(t, 6, []int{0, 1, 2})
+	if err := table.AddChips(players[0], 50); err != nil {
+		t.Fatal(err)
+	}
+	if players[0].Stack() != 150 {
+		t.Errorf("stack = %v, want 150", players[0].Stack())
+	}
+	if err := table.AddChips(newTestPlayer(t, "stranger", 100), 50); err == nil {
+		t.Errorf("Table.AddChips() for a player not seated error = nil, want error")
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.AddChips(players[1], 50); err == nil {
+		t.Errorf("Table.AddChips() during a hand error = nil, want error")
+	}
+	finishHand(t, table)
+	if err := table.CheckConservation(); err != nil {
+		t.Errorf("Table.CheckConservation() error = %v", err)
+	}
+}
+
+func TestTable_Leave_DuringHand(t *testing.T) {
+	table, players := newSeatTestTable(t, 6, []int{0, 1, 2})
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Leave(players[1]); err != nil {
+		t.Fatal(err)
+	}
+	if table.Seats()[1].IsEmpty() || !containsPlayer(table.Players(), players[1]) {
+		t.Errorf("player should stay until the hand ends")
+	}
+	finishHand(t, table)
+	if !table.Seats()[1].IsEmpty() {
+		t.Errorf("seat 1 should be empty after the hand")
+	}
+}
+
+func TestTable_SitOut_DuringHand(t *testing.T) {
+	table, players := newSeatTestTable(t, 6, []int{0, 1, 2})
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SitOut(players[0]); err != nil {
+		t.Fatal(err)
+	}
+	if !players[0].IsActive() {
+		t.Errorf("sit out should take effect from the next hand")
+	}
+	finishHand(t, table)
+	if players[0].IsActive() {
+		t.Errorf("player should be sitting out after the hand")
+	}
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if containsPlayer(table.Players(), players[0]) {
+		t.Errorf("sitting out player should not be dealt in")
+	}
+}
+
+func TestTable_Join_WaitsForBigBlind(t *testing.T) {
+	table, _ := newSeatTestTable(t, 6, []int{0, 1, 2})
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	latecomer := newPlayerWithStack(t, "latecomer", 100)
+	if err := table.Join(4, latecomer); err != nil {
+		t.Fatal(err)
+	}
+	if !table.Seats()[4].IsWaitingForBigBlind() {
+		t.Errorf("latecomer should wait for the big blind")
+	}
+	finishHand(t, table)
+	// ボタン1、スモールブラインド2の次にビッグブラインドが回ってくる
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if table.BigBlindSeat() != 4 {
+		t.Errorf("Table.BigBlindSeat() = %v, want 4", table.BigBlindSeat())
+	}
+	if !containsPlayer(table.Players(), latecomer) || latecomer.Chips() != 10 {
+		t.Errorf("latecomer should play from the big blind")
+	}
+}
+
+func TestTable_MissedBlinds(t *testing.T) {
+	table, players := newSeatTestTable(t, 4, []int{0, 1, 2, 3})
+	// ハンド1: ボタン0、スモールブラインド1、ビッグブラインド2
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SitOut(players[3]); err != nil {
+		t.Fatal(err)
+	}
+	finishHand(t, table)
+	// ハンド2: ビッグブラインドがシットアウト中の席3を通り過ぎる
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	if !table.Seats()[3].MissedBigBlind() {
+		t.Errorf("seat 3 should have missed the big blind")
+	}
+	if err := table.SitIn(players[3]); err != nil {
+		t.Fatal(err)
+	}
+	finishHand(t, table)
+	// ハンド3: 復帰したプレイヤーはビッグブラインドとデッドのスモールブラインドを支払う
+	if err := table.StartHand(); err != nil {
+		t.Fatal(err)
+	}
+	before := players[3].Stack()
+	if err := table.PostBlinds(); err != nil {
+		t.Fatal(err)
+	}
+	if !containsPlayer(table.Players(), players[3]) {
+		t.Fatalf("returning player should be dealt in")
+	}
+	if players[3].Chips() != 10 {
+		t.Errorf("players[3].Chips() = %v, want 10", players[3].Chips())
+	}
+	if paid := before - players[3].Stack(); paid != 15 {
+		t.Errorf("players[3] paid %v, want 15", paid)
+	}
+	if table.Seats()[3].MissedBigBlind() || table.Seats()[3].MissedSmallBlind() {
+		t.Errorf("missed blinds should be cleared after posting")
+	}
+}
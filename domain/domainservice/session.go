@@ -0,0 +1,258 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ハンド中のプレイヤーの判断
+type Decider interface {
+	DecideAction(t *Table, player *entity.Player) Action
+	DecideDiscards(t *Table, player *entity.Player) []*valueobject.Card
+}
+
+// オールインの後にドローを何回行うかを決めるプレイヤー。Deciderがこれを実装していれば、ドローを複数回行える場面で尋ねる
+// 1を返せば同意しない
+type RunsDecider interface {
+	DecideRuns(t *Table, player *entity.Player) int
+}
+
+// ショーダウンで手札を見せるかどうかを決めるプレイヤー。Deciderがこれを実装していなければ、全員が手札を見せる
+type ShowdownDecider interface {
+	// マックできる場面で、手札を見せるならtrueを返す
+	DecideShow(t *Table, player *entity.Player) bool
+	// ショーダウンせずにポットを獲得したときに見せる1枚。見せなければnil
+	DecideShowOne(t *Table, player *entity.Player) *valueobject.Card
+}
+
+// チェックかコールだけを行い、カードを交換しないプレイヤー
+type PassiveDecider struct{}
+
+func (PassiveDecider) DecideAction(t *Table, player *entity.Player) Action {
+	if containsAction(t.PermittedActions(player), ActionCheck) {
+		return Action{Type: ActionCheck}
+	}
+	return Action{Type: ActionCall}
+}
+
+func (PassiveDecider) DecideDiscards(t *Table, player *entity.Player) []*valueobject.Card {
+	return nil
+}
+
+// セッションを終了する条件
+type StopCondition func(s *Session) bool
+
+// 1ハンドの結果
+type HandResult struct {
+	HandNumber int
+	Winners    []*entity.Player
+	Pot        int
+	// ドローを複数回行った場合の、ドローごとの勝者。Winnersはいずれかのドローで勝ったプレイヤー
+	RunWinners [][]*entity.Player
+}
+
+// 同じテーブルで続けてハンドを行うセッション
+type Session struct {
+	table          *Table
+	decider        Decider
+	stopConditions []StopCondition
+}
+
+func NewSession(table *Table) *Session {
+	return &Session{
+		table:   table,
+		decider: PassiveDecider{},
+	}
+}
+
+func (s *Session) SetDecider(decider Decider) {
+	s.decider = decider
+}
+
+func (s *Session) Table() *Table {
+	return s.table
+}
+
+// テーブルでこれまでに始めたハンドの数。最初のハンドは1
+func (s *Session) HandNumber() int {
+	return s.table.HandNumber()
+}
+
+func (s *Session) AddStopCondition(condition StopCondition) {
+	s.stopConditions = append(s.stopConditions, condition)
+}
+
+// maxHandsハンドを終えたら終了する条件
+func MaxHands(maxHands int) StopCondition {
+	return func(s *Session) bool {
+		return s.HandNumber() >= maxHands
+	}
+}
+
+// プレイできるプレイヤーが2人未満になるか、いずれかの終了条件を満たしたかどうか
+func (s *Session) ShouldStop() bool {
+	if s.table.countBigBlindCandidates() < 2 {
+		return true
+	}
+	for _, condition := range s.stopConditions {
+		if condition(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// 1ハンドを最初から最後まで行う
+func (s *Session) PlayHand() (HandResult, error) {
+	if s.ShouldStop() {
+		return HandResult{}, fmt.Errorf("session is over")
+	}
+	if err := s.table.StartHand(); err != nil {
+		return HandResult{}, err
+	}
+	if err := s.table.PostBlinds(); err != nil {
+		return HandResult{}, err
+	}
+	if err := s.table.DealCards(); err != nil {
+		return HandResult{}, err
+	}
+	result, err := s.playUntilComplete()
+	if err != nil {
+		return HandResult{}, err
+	}
+	if err := s.table.EndHand(); err != nil {
+		return HandResult{}, err
+	}
+	s.removeBustedPlayers()
+	return result, nil
+}
+
+// ベッティング、ドロー、ショーダウン、支払いをハンドが終わるまで進める
+func (s *Session) playUntilComplete() (HandResult, error) {
+	result := HandResult{HandNumber: s.HandNumber()}
+	for s.table.Phase() != PhaseComplete {
+		switch s.table.Phase() {
+		case PhaseBetting:
+			player := s.table.Actor()
+			if err := s.table.Act(player, s.decider.DecideAction(s.table, player)); err != nil {
+				return HandResult{}, err
+			}
+		case PhaseDraw:
+			if err := s.agreeToRuns(); err != nil {
+				return HandResult{}, err
+			}
+			player := s.table.Drawer()
+			if err := s.table.Draw(player, s.decider.DecideDiscards(s.table, player)); err != nil {
+				return HandResult{}, err
+			}
+		case PhaseShowdown:
+			if player := s.table.Showdowner(); player != nil {
+				if err := s.showOrMuck(player); err != nil {
+					return HandResult{}, err
+				}
+				break
+			}
+			winners, err := s.table.JudgeWinner()
+			if err != nil {
+				return HandResult{}, err
+			}
+			result.Winners = winners
+		case PhasePayout:
+			uncontested := result.Winners == nil
+			if uncontested {
+				result.Winners = s.table.RemainingPlayers()
+			}
+			result.Pot = s.table.Pot()
+			if s.table.Runs() > 1 {
+				runWinners, err := s.table.DistributeRuns()
+				if err != nil {
+					return HandResult{}, err
+				}
+				result.RunWinners = runWinners
+				result.Winners = nil
+				for _, winners := range runWinners {
+					for _, winner := range winners {
+						if !containsPlayer(result.Winners, winner) {
+							result.Winners = append(result.Winners, winner)
+						}
+					}
+				}
+				break
+			}
+			if err := s.table.DistributeChips(result.Winners); err != nil {
+				return HandResult{}, err
+			}
+			if uncontested {
+				if err := s.showOne(result.Winners[0]); err != nil {
+					return HandResult{}, err
+				}
+			}
+		default:
+			return HandResult{}, fmt.Errorf("unexpected phase %s", s.table.Phase())
+		}
+	}
+	return result, nil
+}
+
+// ショーダウンで手札を見せるかマックする
+func (s *Session) showOrMuck(player *entity.Player) error {
+	decider, ok := s.decider.(ShowdownDecider)
+	if ok && containsAction(s.table.PermittedActions(player), ActionMuck) && !decider.DecideShow(s.table, player) {
+		return s.table.Muck(player)
+	}
+	return s.table.Show(player)
+}
+
+// ショーダウンせずにポットを獲得したプレイヤーが、望めば1枚だけ見せる
+func (s *Session) showOne(winner *entity.Player) error {
+	decider, ok := s.decider.(ShowdownDecider)
+	if !ok {
+		return nil
+	}
+	if card := decider.DecideShowOne(s.table, winner); card != nil {
+		return s.table.ShowOne(winner, card)
+	}
+	return nil
+}
+
+// ドローを複数回行える場面であれば、残っているプレイヤーに回数を尋ねる
+func (s *Session) agreeToRuns() error {
+	decider, ok := s.decider.(RunsDecider)
+	if !ok || !s.table.CanRunMultipleTimes() {
+		return nil
+	}
+	for _, player := range s.table.RemainingPlayers() {
+		times := decider.DecideRuns(s.table, player)
+		if times <= 1 {
+			return nil
+		}
+		if err := s.table.AgreeToRunMultipleTimes(player, times); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 終了条件を満たすまでハンドを続ける
+func (s *Session) Run() ([]HandResult, error) {
+	results := []HandResult{}
+	for !s.ShouldStop() {
+		result, err := s.PlayHand()
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// チップがなくなったプレイヤーを席から外す
+func (s *Session) removeBustedPlayers() {
+	for i, seat := range s.table.seats {
+		if !seat.IsEmpty() && seat.player.Stack() == 0 {
+			s.table.removePlayer(i)
+		}
+	}
+}
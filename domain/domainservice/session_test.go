@@ -0,0 +1,89 @@
+package domainservice
+
+import (
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+func totalStacks(table *Table) int {
+	total := 0
+	for _, seat := range table.Seats() {
+		if !seat.IsEmpty() {
+			total += seat.Player().Stack()
+		}
+	}
+	return total
+}
+
+func TestSession_Run_MaxHands(t *testing.T) {
+	table, err := NewTable("table", []*entity.Player{
+		newPlayerWithStack(t, "alice", 1000),
+		newPlayerWithStack(t, "bob", 1000),
+		newPlayerWithStack(t, "carol", 1000),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := NewSession(table)
+	session.AddStopCondition(MaxHands(5))
+	results, err := session.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 5 {
+		t.Fatalf("len(results) = %v, want 5", len(results))
+	}
+	if session.HandNumber() != table.HandNumber() {
+		t.Errorf("Session.HandNumber() = %v, want %v", session.HandNumber(), table.HandNumber())
+	}
+	for i, result := range results {
+		if result.HandNumber != i+1 {
+			t.Errorf("results[%d].HandNumber = %v, want %v", i, result.HandNumber, i+1)
+		}
+		if result.Pot != 30 {
+			t.Errorf("results[%d].Pot = %v, want 30", i, result.Pot)
+		}
+	}
+	for _, player := range table.Players() {
+		if len(player.Cards()) != 5 {
+			t.Errorf("len(player.Cards()) = %v, want 5", len(player.Cards()))
+		}
+	}
+	if got := len(table.deck); got != 52-15 {
+		t.Errorf("len(deck) = %v, want %v", got, 52-15)
+	}
+	if got := totalStacks(table); got != 3000 {
+		t.Errorf("total stacks = %v, want 3000", got)
+	}
+	if _, err := session.PlayHand(); err == nil {
+		t.Errorf("Session.PlayHand() after stop error = nil, want error")
+	}
+}
+
+func TestSession_Run_UntilOnePlayerRemains(t *testing.T) {
+	table, err := NewTable("table", []*entity.Player{
+		newPlayerWithStack(t, "alice", 10),
+		newPlayerWithStack(t, "bob", 10),
+		newPlayerWithStack(t, "carol", 10),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := NewSession(table)
+	if _, err := session.Run(); err != nil {
+		t.Fatal(err)
+	}
+	remaining := 0
+	for _, seat := range table.Seats() {
+		if !seat.IsEmpty() {
+			remaining++
+		}
+	}
+	if remaining != 1 {
+		t.Errorf("remaining players = %v, want 1", remaining)
+	}
+	if got := totalStacks(table); got != 30 {
+		t.Errorf("total stacks = %v, want 30", got)
+	}
+}
@@ -0,0 +1,57 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// 7と2を含むセブンハイの手札でポットを獲得し、ショーダウンで手札を見せたプレイヤーが、ハンドに参加した他の全員からボーナスを受け取るハウスルール
+// ファイブカードドローでは、ペアもストレートもフラッシュもない7が最も高いカードの手札のうち、2を含むものを7-2とする
+type SevenDeuceBounty struct {
+	amount int
+}
+
+func NewSevenDeuceBounty(amount int) (SevenDeuceBounty, error) {
+	if amount <= 0 {
+		return SevenDeuceBounty{}, fmt.Errorf("bounty must be positive")
+	}
+	return SevenDeuceBounty{amount: amount}, nil
+}
+
+func (b SevenDeuceBounty) Name() string {
+	return "7-2 bounty"
+}
+
+func (b SevenDeuceBounty) Amount() int {
+	return b.amount
+}
+
+// 7-2をショーダウンで見せて勝ったプレイヤーに、ハンドに参加した他のプレイヤーがボーナスを支払う。スタックが足りなければ全額を支払う
+// ショーダウンをせずに獲得した場合は、手札が見えないので支払わない
+func (b SevenDeuceBounty) AfterPayout(t *Table, winners []*entity.Player) error {
+	for _, winner := range winners {
+		if !t.shown[winner] || !isSevenDeuce(winner.Cards()) {
+			continue
+		}
+		for _, player := range t.Players() {
+			if player == winner || player.Stack() == 0 {
+				continue
+			}
+			if _, err := t.PaySidePayment(player, winner, b.amount); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isSevenDeuce(cards []*valueobject.Card) bool {
+	value, err := entity.EvaluateHand(cards)
+	if err != nil || value.Hand() != "ハイカード" {
+		return false
+	}
+	ranks := value.Ranks()
+	return ranks[0] == valueobject.ValueRankMap()["7"] && ranks[len(ranks)-1] == valueobject.ValueRankMap()["2"]
+}
@@ -0,0 +1,123 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSevenDeuceBounty(t *testing.T) {
+	tests := []struct {
+		name   string
+		stacks []int
+		hand   string
+		// trueならドロー後にplayers[2]がベットして他のプレイヤーがコールし、players[2]が見せて他はマックする
+		// falseならplayers[2]以外がドロー前にフォールドする
+		showdown   bool
+		wantStacks []int
+	}{
+		{
+			name:       "7と2を含むセブンハイを見せて勝てば、他の全員からボーナスを受け取る",
+			stacks:     []int{100, 100, 100},
+			hand:       "7s2d5h4c3c",
+			showdown:   true,
+			wantStacks: []int{60, 60, 180},
+		},
+		{
+			name:       "7のペアと2では受け取らない",
+			stacks:     []int{100, 100, 100},
+			hand:       "7s7d2hKc9c",
+			showdown:   true,
+			wantStacks: []int{70, 70, 160},
+		},
+		{
+			name:       "7より高いカードがあれば受け取らない",
+			stacks:     []int{100, 100, 100},
+			hand:       "7s2dKhQc9c",
+			showdown:   true,
+			wantStacks: []int{70, 70, 160},
+		},
+		{
+			name:       "ショーダウンをせずに勝った場合は受け取らない",
+			stacks:     []int{100, 100, 100},
+			hand:       "7s2d5h4c3c",
+			showdown:   false,
+			wantStacks: []int{100, 95, 105},
+		},
+		{
+			name:       "スタックが足りないプレイヤーは全額を支払う",
+			stacks:     []int{33, 100, 100},
+			hand:       "7s2d5h4c3c",
+			showdown:   true,
+			wantStacks: []int{0, 60, 173},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounty, err := NewSevenDeuceBounty(10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, tt.stacks, bounty)
+			if err := table.DealCards(); err != nil {
+				t.Fatal(err)
+			}
+			winner := players[2]
+			for table.Phase() == PhaseBetting || table.Phase() == PhaseDraw {
+				if table.Phase() == PhaseDraw {
+					if err := table.Draw(table.Drawer(), nil); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				actor := table.Actor()
+				action := Action{Type: ActionFold}
+				if tt.showdown {
+					action = PassiveDecider{}.DecideAction(table, actor)
+					if table.BettingRound() == 2 && actor == winner && table.CurrentBet() == 0 {
+						action = Action{Type: ActionBet, Amount: 20}
+					}
+				}
+				if err := table.Act(actor, action); err != nil {
+					t.Fatal(err)
+				}
+			}
+			winner.ReturnCards()
+			for _, card := range mustParseCards(t, tt.hand) {
+				winner.DrawCard(card)
+			}
+			winners := table.RemainingPlayers()
+			if tt.showdown {
+				for table.Showdowner() != nil {
+					showdowner := table.Showdowner()
+					if showdowner == winner {
+						err = table.Show(showdowner)
+					} else {
+						err = table.Muck(showdowner)
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+				if winners, err = table.JudgeWinner(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := table.DistributeChips(winners); err != nil {
+				t.Fatal(err)
+			}
+			got := []int{}
+			for _, player := range players {
+				got = append(got, player.Stack())
+			}
+			if !reflect.DeepEqual(got, tt.wantStacks) {
+				t.Errorf("stacks = %v, want %v", got, tt.wantStacks)
+			}
+			if err := table.EndHand(); err != nil {
+				t.Errorf("Table.EndHand() error = %v", err)
+			}
+		})
+	}
+	if _, err := NewSevenDeuceBounty(0); err == nil {
+		t.Errorf("NewSevenDeuceBounty() error = nil, want error")
+	}
+}
@@ -0,0 +1,147 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ショーダウンやハンドの後にプレイヤーが見せたカード、またはマックしたこと。ハンドヒストリーに記録する内容
+type Reveal struct {
+	Player *entity.Player
+	// 見せたカード。マックした場合はnil
+	Cards  []*valueobject.Card
+	Mucked bool
+}
+
+// ショーダウンを始める。最後のベッティングラウンドで最後にベットやレイズをしたプレイヤーから、
+// いなければボタンの左のプレイヤーから順に手札を見せる
+// オールインしたプレイヤーがいれば、アクションが終わった時点で全員の手札を自動的に見せる
+func (t *Table) startShowdown() {
+	t.phase = PhaseShowdown
+	start := 0
+	for i, player := range t.players {
+		if player == t.lastAggressor && !t.folded[player] {
+			start = i
+		}
+	}
+	t.showdownOrder = []*entity.Player{}
+	for i := 0; i < len(t.players); i++ {
+		if player := t.players[(start+i)%len(t.players)]; !t.folded[player] {
+			t.showdownOrder = append(t.showdownOrder, player)
+		}
+	}
+	t.shower = 0
+	for _, player := range t.showdownOrder {
+		if player.Stack() == 0 {
+			t.showAll()
+			return
+		}
+	}
+}
+
+// ショーダウンで手札を見せる順番
+func (t *Table) ShowdownOrder() []*entity.Player {
+	return append([]*entity.Player{}, t.showdownOrder...)
+}
+
+// ショーダウンで現在手札を見せるかマックするプレイヤー。ショーダウン中でないか、全員が終えていればnil
+func (t *Table) Showdowner() *entity.Player {
+	if t.phase != PhaseShowdown || t.shower >= len(t.showdownOrder) {
+		return nil
+	}
+	return t.showdownOrder[t.shower]
+}
+
+// 現在の番のプレイヤーが手札を見せる
+func (t *Table) Show(player *entity.Player) error {
+	if err := t.requireShowdowner("show", player); err != nil {
+		return err
+	}
+	t.show(player)
+	t.shower++
+	return nil
+}
+
+// 現在の番のプレイヤーが手札を見せずにマックし、ポットを放棄する
+// 誰かが手札を見せた後でなければマックできない
+func (t *Table) Muck(player *entity.Player) error {
+	if err := t.requireShowdowner("muck", player); err != nil {
+		return err
+	}
+	if !t.canMuck() {
+		return fmt.Errorf("first player to show cannot muck")
+	}
+	t.folded[player] = true
+	t.reveals = append(t.reveals, Reveal{Player: player, Mucked: true})
+	t.shower++
+	return nil
+}
+
+func (t *Table) requireShowdowner(operation string, player *entity.Player) error {
+	if err := t.requirePhase(operation, PhaseShowdown); err != nil {
+		return err
+	}
+	if t.Showdowner() != player {
+		return fmt.Errorf("it is not %s's turn to show", player.Name())
+	}
+	return nil
+}
+
+func (t *Table) canMuck() bool {
+	return len(t.reveals) > 0
+}
+
+func (t *Table) show(player *entity.Player) {
+	t.shown[player] = true
+	t.reveals = append(t.reveals, Reveal{Player: player, Cards: append([]*valueobject.Card{}, player.Cards()...)})
+}
+
+// まだ手札を見せていないプレイヤー全員が順に手札を見せる
+func (t *Table) showAll() {
+	for ; t.shower < len(t.showdownOrder); t.shower++ {
+		t.show(t.showdownOrder[t.shower])
+	}
+}
+
+// ショーダウンせずにポットを獲得したプレイヤーが、手札のうち1枚だけを任意で見せる
+func (t *Table) ShowOne(player *entity.Player, card *valueobject.Card) error {
+	if err := t.requirePhase("show one card", PhasePayout, PhaseComplete); err != nil {
+		return err
+	}
+	remaining := t.RemainingPlayers()
+	if len(remaining) != 1 || remaining[0] != player || len(t.showdownOrder) > 0 {
+		return fmt.Errorf("only a player who won without a showdown can show one card")
+	}
+	if len(t.ShownCards(player)) > 0 {
+		return fmt.Errorf("a card is already shown")
+	}
+	if !valueobject.ContainsCard(player.Cards(), card) {
+		return fmt.Errorf("card is not in the hand")
+	}
+	t.shownCards[player] = []*valueobject.Card{card}
+	t.reveals = append(t.reveals, Reveal{Player: player, Cards: []*valueobject.Card{card}})
+	return nil
+}
+
+// 現在のハンドでプレイヤーが他のプレイヤーに見せたカード
+func (t *Table) ShownCards(player *entity.Player) []*valueobject.Card {
+	if t.shown[player] {
+		return player.Cards()
+	}
+	return t.shownCards[player]
+}
+
+// viewerから見えるplayerの手札。自分の手札はすべて見え、他のプレイヤーの手札は見せたカードだけが見える
+func (t *Table) VisibleCards(viewer, player *entity.Player) []*valueobject.Card {
+	if viewer == player {
+		return player.Cards()
+	}
+	return t.ShownCards(player)
+}
+
+// 現在のハンドで見せたカードとマックを、行われた順に返す
+func (t *Table) Reveals() []Reveal {
+	return append([]Reveal{}, t.reveals...)
+}
@@ -0,0 +1,302 @@
+package domainservice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ドロー前に全員がオールインし、ショーダウンの直前まで進めてから、各プレイヤーの手札をhandsに入れ替える
+func newShowdownTestTable(t *testing.T, stacks []int, hands []string) (*Table, []*entity.Player) {
+	t.Helper()
+	table, players := newAllInTestTable(t, stacks)
+	playAllInUntil(t, table, PhaseShowdown)
+	for i, player := range players {
+		player.ReturnCards()
+		for _, card := range mustParseCards(t, hands[i]) {
+			player.DrawCard(card)
+		}
+	}
+	return table, players
+}
+
+// ドロー後のベッティングラウンドでbettorだけがベットし、他のプレイヤーがコールしてショーダウンまで進める。bettorが-1なら全員チェックする
+func newShowdownOrderTestTable(t *testing.T, bettor int) (*Table, []*entity.Player) {
+	t.Helper()
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{1000, 1000, 1000})
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	for table.Phase() != PhaseShowdown {
+		switch table.Phase() {
+		case PhaseBetting:
+			actor := table.Actor()
+			action := PassiveDecider{}.DecideAction(table, actor)
+			if table.BettingRound() == 2 && bettor >= 0 && actor == players[bettor] && table.CurrentBet() == 0 {
+				action = Action{Type: ActionBet, Amount: 20}
+			}
+			if err := table.Act(actor, action); err != nil {
+				t.Fatal(err)
+			}
+		case PhaseDraw:
+			if err := table.Draw(table.Drawer(), nil); err != nil {
+				t.Fatal(err)
+			}
+		default:
+			t.Fatalf("Table.Phase() = %v, want %v", table.Phase(), PhaseShowdown)
+		}
+	}
+	return table, players
+}
+
+func TestTable_ShowdownOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		bettor int
+		want   []int
+	}{
+		{
+			name:   "ベットがなければボタンの左から見せる",
+			bettor: -1,
+			want:   []int{1, 2, 0},
+		},
+		{
+			name:   "最後にベットしたプレイヤーから見せる",
+			bettor: 2,
+			want:   []int{2, 0, 1},
+		},
+		{
+			name:   "ボタンがベットすればボタンから見せる",
+			bettor: 0,
+			want:   []int{0, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newShowdownOrderTestTable(t, tt.bettor)
+			want := []*entity.Player{}
+			for _, i := range tt.want {
+				want = append(want, players[i])
+			}
+			if got := table.ShowdownOrder(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Table.ShowdownOrder() = %v, want %v", got, want)
+			}
+			if got := table.Showdowner(); got != want[0] {
+				t.Errorf("Table.Showdowner() = %v, want %v", got.Name(), want[0].Name())
+			}
+		})
+	}
+}
+
+func TestTable_Muck(t *testing.T) {
+	table, players := newShowdownOrderTestTable(t, 2)
+	first, second, third := players[2], players[0], players[1]
+	if got, want := table.PermittedActions(first), []ActionType{ActionShow}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PermittedActions() = %v, want %v", got, want)
+	}
+	if err := table.Muck(first); err == nil {
+		t.Errorf("Table.Muck() error = nil for the first player, want error")
+	}
+	if err := table.Show(second); err == nil {
+		t.Errorf("Table.Show() error = nil out of turn, want error")
+	}
+	if err := table.Show(first); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := table.PermittedActions(second), []ActionType{ActionShow, ActionMuck}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PermittedActions() = %v, want %v", got, want)
+	}
+	if err := table.Muck(second); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Muck(third); err != nil {
+		t.Fatal(err)
+	}
+	if table.Showdowner() != nil {
+		t.Errorf("Table.Showdowner() = %v, want nil", table.Showdowner().Name())
+	}
+	// マックした手札は他のプレイヤーに見えない
+	if got := table.VisibleCards(first, second); got != nil {
+		t.Errorf("Table.VisibleCards() = %v, want nil", got)
+	}
+	if got := table.VisibleCards(second, second); !reflect.DeepEqual(got, second.Cards()) {
+		t.Errorf("Table.VisibleCards() = %v, want own cards %v", got, second.Cards())
+	}
+	if got := table.VisibleCards(second, first); !reflect.DeepEqual(got, first.Cards()) {
+		t.Errorf("Table.VisibleCards() = %v, want shown cards %v", got, first.Cards())
+	}
+	want := []Reveal{
+		{Player: first, Cards: first.Cards()},
+		{Player: second, Mucked: true},
+		{Player: third, Mucked: true},
+	}
+	if got := table.Reveals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.Reveals() = %v, want %v", got, want)
+	}
+	// マックしたプレイヤーはポットを放棄する
+	winners, err := table.JudgeWinner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(winners, []*entity.Player{first}) {
+		t.Errorf("Table.JudgeWinner() = %v, want %v", winners, []*entity.Player{first})
+	}
+	if err := table.DistributeChips(winners); err != nil {
+		t.Fatal(err)
+	}
+	if got := first.Stack(); got != 1060 {
+		t.Errorf("winner stack = %v, want 1060", got)
+	}
+}
+
+func TestTable_JudgeWinner_ShowsRemainingHands(t *testing.T) {
+	table, players := newShowdownOrderTestTable(t, -1)
+	if err := table.Show(players[1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.JudgeWinner(); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range players {
+		if got := table.ShownCards(player); !reflect.DeepEqual(got, player.Cards()) {
+			t.Errorf("Table.ShownCards(%s) = %v, want %v", player.Name(), got, player.Cards())
+		}
+	}
+}
+
+func TestTable_ShowdownWithAllIn(t *testing.T) {
+	table, players := newAllInTestTable(t, []int{50, 100})
+	playAllInUntil(t, table, PhaseShowdown)
+	// オールインがあれば全員の手札を自動的に見せる
+	if table.Showdowner() != nil {
+		t.Errorf("Table.Showdowner() = %v, want nil", table.Showdowner().Name())
+	}
+	for _, player := range players {
+		if got := table.VisibleCards(nil, player); !reflect.DeepEqual(got, player.Cards()) {
+			t.Errorf("Table.VisibleCards(%s) = %v, want %v", player.Name(), got, player.Cards())
+		}
+	}
+	if got := len(table.Reveals()); got != 2 {
+		t.Errorf("len(Table.Reveals()) = %v, want 2", got)
+	}
+}
+
+func TestTable_ShowOne(t *testing.T) {
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100})
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	for table.Phase() == PhaseBetting {
+		if err := table.Act(table.Actor(), Action{Type: ActionFold}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	winner, loser := players[2], players[0]
+	if err := table.DistributeChips(table.RemainingPlayers()); err != nil {
+		t.Fatal(err)
+	}
+	if got := table.VisibleCards(loser, winner); got != nil {
+		t.Errorf("Table.VisibleCards() = %v before showing, want nil", got)
+	}
+	tests := []struct {
+		name    string
+		player  *entity.Player
+		card    *valueobject.Card
+		wantErr bool
+	}{
+		{
+			name:    "フォールドしたプレイヤーは見せられない",
+			player:  loser,
+			card:    loser.Cards()[0],
+			wantErr: true,
+		},
+		{
+			name:    "手札にないカードは見せられない",
+			player:  winner,
+			card:    loser.Cards()[0],
+			wantErr: true,
+		},
+		{
+			name:   "ポットを獲得したプレイヤーが、同じスートとランクのカードを指定して1枚見せる",
+			player: winner,
+			card:   valueobject.NewCard(winner.Cards()[2].Suit(), winner.Cards()[2].Value()),
+		},
+		{
+			name:    "2枚目は見せられない",
+			player:  winner,
+			card:    winner.Cards()[3],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := table.ShowOne(tt.player, tt.card); (err != nil) != tt.wantErr {
+				t.Errorf("Table.ShowOne() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	want := []*valueobject.Card{winner.Cards()[2]}
+	if got := table.VisibleCards(loser, winner); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.VisibleCards() = %v, want %v", got, want)
+	}
+	if got := table.Reveals(); !reflect.DeepEqual(got, []Reveal{{Player: winner, Cards: want}}) {
+		t.Errorf("Table.Reveals() = %v, want %v", got, []Reveal{{Player: winner, Cards: want}})
+	}
+
+	// ショーダウンで勝ったプレイヤーは1枚だけ見せることはできない
+	table, players = newShowdownOrderTestTable(t, -1)
+	winners, err := table.JudgeWinner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.DistributeChips(winners); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.ShowOne(winners[0], winners[0].Cards()[0]); err == nil {
+		t.Errorf("Table.ShowOne() error = nil after a showdown, want error")
+	}
+}
+
+// マックできる場面では常にマックし、ショーダウンせずに勝てば1枚目を見せるプレイヤー
+type muckingDecider struct {
+	PassiveDecider
+}
+
+func (muckingDecider) DecideShow(t *Table, player *entity.Player) bool {
+	return false
+}
+
+func (muckingDecider) DecideShowOne(t *Table, player *entity.Player) *valueobject.Card {
+	return player.Cards()[0]
+}
+
+func TestSession_Showdown(t *testing.T) {
+	table, err := NewTableWithSeats("table", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetBlinds(Blinds{SmallBlind: 5, BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	for seat := 0; seat < 3; seat++ {
+		if err := table.Join(seat, newPlayerWithStack(t, "player", 1000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	session := NewSession(table)
+	session.SetDecider(muckingDecider{})
+	result, err := session.PlayHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 最初に見せたプレイヤー以外はマックし、最初に見せたプレイヤーが勝つ
+	reveals := table.Reveals()
+	if len(reveals) != 3 || reveals[0].Mucked || !reveals[1].Mucked || !reveals[2].Mucked {
+		t.Errorf("Table.Reveals() = %v, want one show and two mucks", reveals)
+	}
+	if !reflect.DeepEqual(result.Winners, []*entity.Player{reveals[0].Player}) {
+		t.Errorf("HandResult.Winners = %v, want %v", result.Winners, reveals[0].Player)
+	}
+}
@@ -0,0 +1,68 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// 決まった人数が集まると自動的に始まる1テーブルのトーナメント (シットアンドゴー)
+// 2人で行えばヘッズアップの対戦になる
+type SitAndGo struct {
+	*Tournament
+	players int
+}
+
+// シットアンドゴーの結果
+type SitAndGoResult struct {
+	Standings []Standing
+	// 優勝者が決まるまでに行ったハンドの数
+	Hands int
+}
+
+func NewSitAndGo(config TournamentConfig, players int) (*SitAndGo, error) {
+	if players < minSeats || players > maxSeats {
+		return nil, fmt.Errorf("number of players must be between %d and %d", minSeats, maxSeats)
+	}
+	table, err := NewTableWithSeats("sit-and-go", players)
+	if err != nil {
+		return nil, err
+	}
+	tournament, err := NewTournament(table, config)
+	if err != nil {
+		return nil, err
+	}
+	return &SitAndGo{Tournament: tournament, players: players}, nil
+}
+
+// 2人で行うシットアンドゴー
+func NewHeadsUpMatch(config TournamentConfig) (*SitAndGo, error) {
+	return NewSitAndGo(config, 2)
+}
+
+// 参加を受け付け、決まった人数が集まったらトーナメントを始める
+func (s *SitAndGo) Register(player *entity.Player) error {
+	if err := s.register(player, s.players); err != nil {
+		return err
+	}
+	if len(s.entrants) < s.players {
+		return nil
+	}
+	return s.Start()
+}
+
+func (s *SitAndGo) IsStarted() bool {
+	return s.started
+}
+
+// 優勝者が決まるまでハンドを続ける
+func (s *SitAndGo) Run() (SitAndGoResult, error) {
+	if !s.started {
+		return SitAndGoResult{}, fmt.Errorf("waiting for %d more players", s.players-len(s.entrants))
+	}
+	standings, err := s.Tournament.Run()
+	if err != nil {
+		return SitAndGoResult{}, err
+	}
+	return SitAndGoResult{Standings: standings, Hands: s.table.HandNumber()}, nil
+}
@@ -0,0 +1,84 @@
+package domainservice
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewSitAndGo(t *testing.T) {
+	config := TournamentConfig{BuyIn: 100, StartingStack: 1000, Schedule: testSchedule, Payouts: []int{10000}}
+	for _, players := range []int{1, maxSeats + 1} {
+		if _, err := NewSitAndGo(config, players); err == nil {
+			t.Errorf("NewSitAndGo(%d players) error = nil, want error", players)
+		}
+	}
+}
+
+func TestSitAndGo_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int
+		payouts []int
+		decider Decider
+	}{
+		{
+			name:    "ヘッズアップ",
+			players: 2,
+			payouts: []int{10000},
+			decider: allInDecider{},
+		},
+		{
+			name:    "6人",
+			players: 6,
+			payouts: []int{6500, 3500},
+			decider: allInDecider{},
+		},
+		{
+			name:    "10人で全員がドローする",
+			players: 10,
+			payouts: []int{5000, 3000, 2000},
+			decider: drawingAllInDecider{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := TournamentConfig{BuyIn: 100, StartingStack: 1000, Schedule: testSchedule, Payouts: tt.payouts}
+			sitAndGo, err := NewSitAndGo(config, tt.players)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sitAndGo.SetDecider(tt.decider)
+			for i := 0; i < tt.players; i++ {
+				if sitAndGo.IsStarted() {
+					t.Fatalf("started with %d players", i)
+				}
+				if _, err := sitAndGo.Run(); err == nil {
+					t.Errorf("SitAndGo.Run() before enough players error = nil, want error")
+				}
+				if err := sitAndGo.Register(newTestPlayer(t, fmt.Sprintf("player%d", i), 1000)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !sitAndGo.IsStarted() {
+				t.Fatalf("SitAndGo.IsStarted() = false, want true")
+			}
+			if err := sitAndGo.Register(newTestPlayer(t, "late", 1000)); err == nil {
+				t.Errorf("SitAndGo.Register() after the start error = nil, want error")
+			}
+			result, err := sitAndGo.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Standings) != tt.players || result.Hands == 0 {
+				t.Fatalf("result = %+v", result)
+			}
+			winner := result.Standings[0]
+			if winner.Player.Stack() != 1000*tt.players {
+				t.Errorf("winner stack = %v, want %v", winner.Player.Stack(), 1000*tt.players)
+			}
+			if winner.Prize.Amount() != int64(100*tt.players*tt.payouts[0]/10000) {
+				t.Errorf("winner prize = %v", winner.Prize)
+			}
+		})
+	}
+}
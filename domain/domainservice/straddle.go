@@ -0,0 +1,66 @@
+package domainservice
+
+import (
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+type StraddlePosition int
+
+const (
+	// ビッグブラインドの次のプレイヤー (アンダーザガン) がストラドルする
+	UnderTheGunStraddle StraddlePosition = iota
+	// ボタンがストラドルする (ミシシッピストラドル)。ドロー前はスモールブラインドからアクションする
+	MississippiStraddle
+)
+
+// 任意のストラドルを認めるハウスルール
+type Straddle struct {
+	Position StraddlePosition
+	// ストラドルの額がビッグブラインドの何倍か。0なら2倍
+	Multiplier int
+	// プレイヤーがストラドルするかどうかを決める。nilなら常にストラドルする
+	Decide func(t *Table, player *entity.Player) bool
+}
+
+func (s Straddle) Name() string {
+	return "straddle"
+}
+
+func (s Straddle) BeforePosting(t *Table) (bool, error) {
+	return false, nil
+}
+
+// ストラドルできる位置のプレイヤーが望めばストラドルする
+// ブラインドがないハンドや、その位置がブラインドと重なる場合、スタックが足りない場合はストラドルしない
+func (s Straddle) AfterPosting(t *Table) error {
+	if t.CurrentBet() == 0 {
+		return nil
+	}
+	player := s.player(t)
+	if player == nil || player == t.livePlayerAt(t.smallBlindSeat) || player == t.livePlayerAt(t.bigBlindSeat) {
+		return nil
+	}
+	multiplier := s.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	amount := t.blinds.BigBlind * multiplier
+	if player.Stack() < amount || (s.Decide != nil && !s.Decide(t, player)) {
+		return nil
+	}
+	return t.PostStraddle(player, amount)
+}
+
+// ストラドルできる位置のプレイヤー
+func (s Straddle) player(t *Table) *entity.Player {
+	if s.Position == MississippiStraddle {
+		return t.livePlayerAt(t.button)
+	}
+	bigBlind := t.livePlayerAt(t.bigBlindSeat)
+	for i, player := range t.players {
+		if player == bigBlind {
+			return t.players[(i+1)%len(t.players)]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package domainservice
+
+import (
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+func TestStraddle(t *testing.T) {
+	decline := func(t *Table, player *entity.Player) bool { return false }
+	tests := []struct {
+		name   string
+		stacks []int
+		rule   Straddle
+		// ストラドルするプレイヤーとドロー前に最初にアクションするプレイヤーの席。ストラドルしなければstraddlerは-1
+		straddler   int
+		firstActor  int
+		wantBet     int
+		wantMinimum int
+	}{
+		{
+			name:        "アンダーザガンがビッグブラインドの2倍をストラドルし、その次からアクションする",
+			stacks:      []int{100, 100, 100, 100},
+			rule:        Straddle{},
+			straddler:   3,
+			firstActor:  0,
+			wantBet:     20,
+			wantMinimum: 40,
+		},
+		{
+			name:        "倍率を指定できる",
+			stacks:      []int{100, 100, 100, 100},
+			rule:        Straddle{Multiplier: 3},
+			straddler:   3,
+			firstActor:  0,
+			wantBet:     30,
+			wantMinimum: 60,
+		},
+		{
+			name:        "ミシシッピストラドルではボタンがストラドルし、スモールブラインドからアクションする",
+			stacks:      []int{100, 100, 100, 100},
+			rule:        Straddle{Position: MississippiStraddle},
+			straddler:   0,
+			firstActor:  1,
+			wantBet:     20,
+			wantMinimum: 40,
+		},
+		{
+			name:        "ストラドルしないことを選べる",
+			stacks:      []int{100, 100, 100, 100},
+			rule:        Straddle{Decide: decline},
+			straddler:   -1,
+			firstActor:  3,
+			wantBet:     10,
+			wantMinimum: 20,
+		},
+		{
+			name:        "スタックが足りなければストラドルしない",
+			stacks:      []int{100, 100, 100, 15},
+			rule:        Straddle{},
+			straddler:   -1,
+			firstActor:  3,
+			wantBet:     10,
+			wantMinimum: 20,
+		},
+		{
+			name:        "ヘッズアップではボタンがスモールブラインドなのでミシシッピストラドルしない",
+			stacks:      []int{100, 100},
+			rule:        Straddle{Position: MississippiStraddle},
+			straddler:   -1,
+			firstActor:  0,
+			wantBet:     10,
+			wantMinimum: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, tt.stacks, tt.rule)
+			var straddler *entity.Player
+			if tt.straddler >= 0 {
+				straddler = players[tt.straddler]
+			}
+			if got := table.Straddler(); got != straddler {
+				t.Errorf("Table.Straddler() = %v, want %v", got, straddler)
+			}
+			if err := table.DealCards(); err != nil {
+				t.Fatal(err)
+			}
+			if got := table.Actor(); got != players[tt.firstActor] {
+				t.Errorf("Table.Actor() = %v, want %v", got.Name(), players[tt.firstActor].Name())
+			}
+			if got := table.CurrentBet(); got != tt.wantBet {
+				t.Errorf("Table.CurrentBet() = %v, want %v", got, tt.wantBet)
+			}
+			if minimum, _ := table.RaiseRange(players[0]); minimum != tt.wantMinimum {
+				t.Errorf("minimum raise = %v, want %v", minimum, tt.wantMinimum)
+			}
+		})
+	}
+}
+
+func TestStraddle_StraddlerActsLast(t *testing.T) {
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100, 100}, Straddle{})
+	if err := table.DealCards(); err != nil {
+		t.Fatal(err)
+	}
+	// ボタン、スモールブラインド、ビッグブラインドがコールした後に、ストラドルしたプレイヤーがアクションする
+	for _, seat := range []int{0, 1, 2} {
+		if got := table.Actor(); got != players[seat] {
+			t.Fatalf("Table.Actor() = %v, want %v", got.Name(), players[seat].Name())
+		}
+		if err := table.Act(table.Actor(), Action{Type: ActionCall}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := table.Actor(); got != players[3] {
+		t.Fatalf("Table.Actor() = %v, want %v", got, players[3].Name())
+	}
+	if err := table.Act(table.Actor(), Action{Type: ActionCheck}); err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Pot(); got != 80 {
+		t.Errorf("Table.Pot() = %v, want 80", got)
+	}
+}
+
+func TestTable_PostStraddle(t *testing.T) {
+	table, players := newPostedTestTable(t, Blinds{SmallBlind: 5, BigBlind: 10}, []int{100, 100, 100, 100})
+	tests := []struct {
+		name    string
+		player  *entity.Player
+		amount  int
+		wantErr bool
+	}{
+		{
+			name:    "ビッグブラインドはストラドルできない",
+			player:  players[2],
+			amount:  20,
+			wantErr: true,
+		},
+		{
+			name:    "ビッグブラインドの2倍に満たない額ではストラドルできない",
+			player:  players[3],
+			amount:  15,
+			wantErr: true,
+		},
+		{
+			name:    "スタックを超える額ではストラドルできない",
+			player:  players[3],
+			amount:  200,
+			wantErr: true,
+		},
+		{
+			name:   "ビッグブラインドの次のプレイヤーがストラドルする",
+			player: players[3],
+			amount: 20,
+		},
+		{
+			name:    "ストラドルは1人だけ",
+			player:  players[0],
+			amount:  40,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table.phase = PhasePosting
+			err := table.PostStraddle(tt.player, tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.PostStraddle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	table.phase = PhaseDealing
+	if err := table.PostStraddle(players[0], 20); err == nil {
+		t.Errorf("Table.PostStraddle() error = nil after posting, want error")
+	}
+}
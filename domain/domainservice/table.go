@@ -2,6 +2,7 @@ package domainservice
 
 import (
 	"fmt"
+	"time"
 
 	"math/rand"
 
@@ -10,16 +11,127 @@ import (
 )
 
 type Table struct {
-	uuid    string
-	deck    []*valueobject.Card
+	uuid  string
+	deck  []*valueobject.Card
+	seats []*Seat
+	// 現在のハンドに参加しているプレイヤー
 	players []*entity.Player
+	// 終了したベッティングラウンドから集めたチップ
+	pot        int
+	blinds     Blinds
+	buttonRule ButtonRule
+	// ボタンとブラインドの席番号。最初のハンドが始まる前は-1
+	button         int
+	smallBlindSeat int
+	bigBlindSeat   int
+	phase          Phase
+	// ベッティングラウンドの状態
+	bettingRound  int
+	actor         int
+	folded        map[*entity.Player]bool
+	acted         map[*entity.Player]bool
+	lastRaiseSize int
+	// 最後にフルレイズが行われたときの掛け金。これに満たないレイズはアクションを再開しない
+	fullRaiseBet int
+	// このラウンドで行われたベットとレイズの回数
+	raiseCount       int
+	bettingStructure BettingStructure
+	handNumber       int
+	// 現在のハンドで各プレイヤーがポットに入れた額
+	contributions map[*entity.Player]int
+	// contributionsのうち、アンティやデッドのスモールブラインドなど掛け金にならずにポットに入れた額
+	deadMoney      map[*entity.Player]int
+	ledger         *Ledger
+	rake           Rake
+	timeCollection TimeCollection
+	// 現在のハンドでポットから取った手数料
+	handRake int
+	// タイムチャージを最後に取った時刻
+	timeCollectedAt map[*entity.Player]time.Time
+	clock           func() time.Time
+	buyInLimits     BuyInLimits
+	// キャッシュゲームでのチップ1枚の金額
+	chipValue valueobject.Money
+	// キャッシュアウトしたときのスタックと時刻
+	cashOuts map[*entity.Player]cashOut
+	// 山札が足りなくなったときの扱いと、現在の山札に使っているデッキの組数
+	deckExhaustion DeckExhaustion
+	decks          int
+	// ドローの状態
+	drawer int
+	muck   []*valueobject.Card
+	// オールインの後にドローを行う回数と、その同意
+	runs          int
+	runAgreements map[*entity.Player]int
+	// ドローを複数回行ったときの、ドローごとの手札
+	runHands map[*entity.Player][][]*valueobject.Card
+	// 追加した順のハウスルール
+	houseRules []HouseRule
+	// 現在のハンドでストラドルしたプレイヤー
+	straddler *entity.Player
+	// 現在のベッティングラウンドで最後にベットやレイズをしたプレイヤー
+	lastAggressor *entity.Player
+	// ショーダウンの状態
+	showdownOrder []*entity.Player
+	shower        int
+	// 手札をすべて見せたプレイヤーと、1枚だけ見せたプレイヤーのカード
+	shown      map[*entity.Player]bool
+	shownCards map[*entity.Player][]*valueobject.Card
+	reveals    []Reveal
+	// これまでに起きたミスディールと、ミスディールで巻き戻すためのブラインドの支払い前の状態
+	misdeals     []Misdeal
+	missedBlinds map[*Seat]missedBlinds
 }
 
-func NewTable(uuid string, players []*entity.Player) *Table {
+// playersを先頭の席から順に座らせたテーブルを作る。山札のカードで全員に配れない人数ならエラーを返す
+func NewTable(uuid string, players []*entity.Player) (*Table, error) {
+	t := newTable(uuid, maxSeats)
+	if len(players)*cardsPerHand > t.deckCapacity() {
+		return nil, fmt.Errorf("not enough cards in deck to deal to %d players", len(players))
+	}
+	for i, player := range players {
+		t.seats[i].player = player
+		player.SitIn()
+		t.record(LedgerBuyIn, player, player.Stack())
+	}
+	t.players = players
+	return t, nil
+}
+
+// 空席だけのテーブルを作る
+func NewTableWithSeats(uuid string, numberOfSeats int) (*Table, error) {
+	if numberOfSeats < minSeats || numberOfSeats > maxSeats {
+		return nil, fmt.Errorf("number of seats must be between %d and %d", minSeats, maxSeats)
+	}
+	return newTable(uuid, numberOfSeats), nil
+}
+
+func newTable(uuid string, numberOfSeats int) *Table {
+	seats := make([]*Seat, numberOfSeats)
+	for i := range seats {
+		seats[i] = &Seat{}
+	}
 	return &Table{
-		uuid:    uuid,
-		deck:    initialDeck,
-		players: players,
+		uuid:             uuid,
+		deck:             initialDeck,
+		decks:            1,
+		seats:            seats,
+		blinds:           defaultBlinds,
+		buttonRule:       DeadButton,
+		bettingStructure: NoLimit{},
+		ledger:           &Ledger{},
+		contributions:    map[*entity.Player]int{},
+		deadMoney:        map[*entity.Player]int{},
+		timeCollectedAt:  map[*entity.Player]time.Time{},
+		clock:            time.Now,
+		cashOuts:         map[*entity.Player]cashOut{},
+		chipValue:        defaultChipValue,
+		runs:             1,
+		runAgreements:    map[*entity.Player]int{},
+		runHands:         map[*entity.Player][][]*valueobject.Card{},
+		button:           -1,
+		smallBlindSeat:   -1,
+		bigBlindSeat:     -1,
 	}
 }
 
@@ -31,14 +143,12 @@ func (t *Table) Players() []*entity.Player {
 	return t.players
 }
 
+func (t *Table) Seats() []*Seat {
+	return t.seats
+}
+
 func createDeck() []*valueobject.Card {
-	deck := []*valueobject.Card{}
-	for _, suit := range valueobject.Suits() {
-		for _, value := range valueobject.Values() {
-			deck = append(deck, valueobject.NewCard(suit, value))
-		}
-	}
-	return deck
+	return valueobject.FullDeck()
 }
 
 func shuffleDeck(deck []*valueobject.Card) []*valueobject.Card {
@@ -53,46 +163,132 @@ func shuffleDeck(deck []*valueobject.Card) []*valueobject.Card {
 
 var initialDeck = shuffleDeck(createDeck())
 
-var ante = 10
-
 func (t *Table) Ante() int {
-	return ante
+	return t.blinds.Ante
 }
 
+// 勝者に配るチップの合計。支払われたアンティとブラインド、掛け金を含む
 func (t *Table) CalculateTotalChips() int {
-	totalChips := ante * len(t.players)
-	for _, player := range t.players {
-		totalChips += player.Chips()
-	}
-	return totalChips
+	return t.Pot()
 }
 
 // 勝ったプレイヤーに賞金を配る
-func (t *Table) DistributeChips(winners []*entity.Player) {
-	totalChips := t.CalculateTotalChips()
-	for _, winner := range winners {
-		winner.Win(totalChips / len(winners))
+// サイドポットは、winnersのうちそのポットを獲得できるプレイヤーに配る。該当者がいなければ、獲得できるプレイヤーの役で決める
+// 割り切れない端数は勝者の並び順に1枚ずつ配る
+func (t *Table) DistributeChips(winners []*entity.Player) error {
+	if err := t.requirePhase("distribute chips", PhasePayout); err != nil {
+		return err
+	}
+	if len(winners) == 0 {
+		return fmt.Errorf("no winners")
+	}
+	if t.runs > 1 {
+		return fmt.Errorf("hand was run %d times", t.runs)
+	}
+	t.CollectBets()
+	pots := t.Pots()
+	t.takeRake(pots)
+	awards := make([][]*entity.Player, len(pots))
+	for i, pot := range pots {
+		potWinners := []*entity.Player{}
+		for _, winner := range winners {
+			if containsPlayer(pot.Eligible, winner) {
+				potWinners = append(potWinners, winner)
+			}
+		}
+		if len(potWinners) == 0 {
+			judged, err := t.judgeWinner(pot.Eligible)
+			if err != nil {
+				return err
+			}
+			potWinners = judged
+		}
+		awards[i] = potWinners
 	}
+	t.pot = 0
+	potWinners := []*entity.Player{}
+	for i, pot := range pots {
+		for j, winner := range awards[i] {
+			winner.Win(t.record(LedgerAward, winner, splitChips(pot.Amount, len(awards[i]), j)))
+			if !containsPlayer(potWinners, winner) {
+				potWinners = append(potWinners, winner)
+			}
+		}
+	}
+	t.phase = PhaseComplete
+	return t.afterPayout(potWinners)
 }
 
-// テーブル上のプレイヤーにカードを配る
-func (t *Table) DealCards() {
-	const numberOfCards = 5
-	for i := 0; i < numberOfCards; i++ {
-		for _, player := range t.players {
-			card := t.deck[0]
-			player.DrawCard(card)
-			t.deck = t.deck[1:]
+func containsPlayer(players []*entity.Player, player *entity.Player) bool {
+	for _, p := range players {
+		if p == player {
+			return true
 		}
 	}
+	return false
+}
+
+// テーブル上のプレイヤーにカードを配り、最初のベッティングラウンドを始める
+// ミスディールにあたる状態が見つかった場合は、ハンドを巻き戻して配り直す
+func (t *Table) DealCards() error {
+	if err := t.requirePhase("deal cards", PhaseDealing); err != nil {
+		return err
+	}
+	for {
+		if err := t.ensureCards(cardsPerHand * len(t.players)); err != nil {
+			return err
+		}
+		reason, misdealt := t.checkDeck()
+		if !misdealt {
+			for i := 0; i < cardsPerHand; i++ {
+				for _, player := range t.players {
+					player.DrawCard(t.deck[0])
+					t.deck = t.deck[1:]
+				}
+			}
+			reason, misdealt = t.checkDealtCards(cardsPerHand)
+		}
+		if !misdealt {
+			break
+		}
+		if err := t.misdeal(reason); err != nil {
+			return err
+		}
+	}
+	betting, err := t.afterDealing()
+	if err != nil {
+		return err
+	}
+	if !betting {
+		// ベッティングラウンドを行わずにドローへ進む
+		t.bettingRound = 1
+		t.endBettingRound()
+		return nil
+	}
+	t.startBettingRound(1)
+	return nil
 }
 
 // テーブル上のプレイヤーの役を判定し、勝者を返す
 func (t *Table) JudgeWinner() ([]*entity.Player, error) {
+	if err := t.requirePhase("judge winner", PhaseShowdown); err != nil {
+		return nil, err
+	}
+	// まだ見せるかマックするかを決めていないプレイヤーは手札を見せる
+	t.showAll()
+	winners, err := t.judgeWinner(t.RemainingPlayers())
+	if err != nil {
+		return nil, err
+	}
+	t.phase = PhasePayout
+	return winners, nil
+}
+
+func (t *Table) judgeWinner(players []*entity.Player) ([]*entity.Player, error) {
 	// step1 まず、各プレイヤーの役を判定し、役の強さを比較する
 	firstStepWinnerCandidates := []*entity.Player{}
 	currentHand := "ハイカード"
-	for _, player := range t.players {
+	for _, player := range players {
 		hands, err := player.JudgeHands()
 		if err != nil {
 			return nil, err
@@ -149,10 +345,29 @@ func (t *Table) JudgeWinner() ([]*entity.Player, error) {
 		return fourthStepWinnerCandidates, nil
 	}
 
-	fourthStepWinnerCandidates, err := entity.DetermineStrongestCardForOnePair(secondStepWinnerCandidates)
-	if err != nil {
-		return nil, err
-	}
+	// 主要部が同じ場合は、残りのカードを高い順に比較する
+	return determineStrongestByHandValue(secondStepWinnerCandidates)
+}
 
-	return fourthStepWinnerCandidates, nil
+// 全てのカードのランクを比較して最も強いプレイヤーを返す。全て同じなら引き分け
+func determineStrongestByHandValue(players []*entity.Player) ([]*entity.Player, error) {
+	winnerCandidates := []*entity.Player{}
+	var best entity.HandValue
+	for _, player := range players {
+		value, err := entity.EvaluateHand(player.Cards())
+		if err != nil {
+			return nil, err
+		}
+		cmp := 1
+		if len(winnerCandidates) > 0 {
+			cmp = value.Compare(best)
+		}
+		if cmp > 0 {
+			winnerCandidates = []*entity.Player{player}
+			best = value
+		} else if cmp == 0 {
+			winnerCandidates = append(winnerCandidates, player)
+		}
+	}
+	return winnerCandidates, nil
 }
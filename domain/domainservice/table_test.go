@@ -267,11 +267,49 @@ func TestTable_JudgeWinner(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "最も強いカードが同じハイカード同士は次に強いカードで決まる",
+			fields: fields{
+				players: []*entity.Player{
+					func() *entity.Player {
+						player := &entity.Player{}
+						player.DrawCard(valueobject.NewCard("club", "2"))
+						player.DrawCard(valueobject.NewCard("heart", "5"))
+						player.DrawCard(valueobject.NewCard("diamond", "9"))
+						player.DrawCard(valueobject.NewCard("spade", "J"))
+						player.DrawCard(valueobject.NewCard("club", "A"))
+						return player
+					}(),
+					func() *entity.Player {
+						player := &entity.Player{}
+						player.DrawCard(valueobject.NewCard("diamond", "3"))
+						player.DrawCard(valueobject.NewCard("spade", "5"))
+						player.DrawCard(valueobject.NewCard("club", "9"))
+						player.DrawCard(valueobject.NewCard("heart", "Q"))
+						player.DrawCard(valueobject.NewCard("spade", "A"))
+						return player
+					}(),
+				},
+			},
+			want: []*entity.Player{
+				func() *entity.Player {
+					player := &entity.Player{}
+					player.DrawCard(valueobject.NewCard("diamond", "3"))
+					player.DrawCard(valueobject.NewCard("spade", "5"))
+					player.DrawCard(valueobject.NewCard("club", "9"))
+					player.DrawCard(valueobject.NewCard("heart", "Q"))
+					player.DrawCard(valueobject.NewCard("spade", "A"))
+					return player
+				}(),
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &Table{
 				players: tt.fields.players,
+				phase:   PhaseShowdown,
 			}
 			got, err := tr.JudgeWinner()
 			if (err != nil) != tt.wantErr {
@@ -0,0 +1,381 @@
+package domainservice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// ブラインドスケジュールの1レベル
+// HandsとDurationのどちらかに達すると次のレベルに進む。最後のレベルはトーナメントが終わるまで続く
+type BlindLevel struct {
+	Blinds Blinds
+	// このレベルを続けるハンド数。0ならハンド数では進まない
+	Hands int
+	// このレベルを続ける時間。0なら時間では進まない
+	Duration time.Duration
+}
+
+type TournamentConfig struct {
+	// 参加費などの金額の通貨。指定しなければDefaultCurrency
+	Currency valueobject.Currency
+	// 参加費。通貨の最小単位の額で、プレイヤーの所持金から支払い、賞金の原資になる
+	BuyIn         int
+	StartingStack int
+	Schedule      []BlindLevel
+	// 順位ごとの賞金の割合。1万分率で、合計が10000になる
+	Payouts []int
+	Rebuy   Rebuy
+	AddOn   AddOn
+	ReEntry ReEntry
+}
+
+// トーナメントの順位
+type Standing struct {
+	Position int
+	Player   *entity.Player
+	Prize    valueobject.Money
+	// 脱落したハンドの番号。優勝者は0
+	EliminatedInHand int
+	// 最初の参加を含めた参加回数
+	Entries int
+	Rebuys  int
+	AddOns  int
+}
+
+// 参加者と賞金、順位の記録。シングルテーブルとマルチテーブルのトーナメントで共通に使う
+type tournamentBook struct {
+	config    TournamentConfig
+	entrants  []*entity.Player
+	prizePool valueobject.Money
+	// 順位が決まったプレイヤー。脱落した順に並ぶ
+	finished []Standing
+	// 次に脱落したプレイヤーの順位。参加とリエントリーで1つ増え、順位が決まるたびに1つ減る
+	nextPosition int
+	started      bool
+	// プレイヤーごとの参加、リバイ、アドオンの回数
+	entries map[*entity.Player]int
+	rebuys  map[*entity.Player]int
+	addOns  map[*entity.Player]int
+}
+
+func newTournamentBook(config TournamentConfig) (*tournamentBook, error) {
+	if config.BuyIn < 0 || config.StartingStack <= 0 {
+		return nil, fmt.Errorf("buy-in must not be negative and starting stack must be positive")
+	}
+	if len(config.Schedule) == 0 {
+		return nil, fmt.Errorf("blind schedule must not be empty")
+	}
+	total := 0
+	for _, payout := range config.Payouts {
+		if payout < 0 {
+			return nil, fmt.Errorf("payout must not be negative")
+		}
+		total += payout
+	}
+	if total != 10000 {
+		return nil, fmt.Errorf("payouts must add up to 10000 basis points")
+	}
+	if err := validateRebuyOptions(config); err != nil {
+		return nil, err
+	}
+	if config.Currency == (valueobject.Currency{}) {
+		config.Currency = valueobject.DefaultCurrency
+	}
+	return &tournamentBook{
+		config:    config,
+		prizePool: valueobject.ZeroMoney(config.Currency),
+		entries:   map[*entity.Player]int{},
+		rebuys:    map[*entity.Player]int{},
+		addOns:    map[*entity.Player]int{},
+	}, nil
+}
+
+func (b *tournamentBook) Entrants() []*entity.Player {
+	return b.entrants
+}
+
+func (b *tournamentBook) PrizePool() valueobject.Money {
+	return b.prizePool
+}
+
+func (b *tournamentBook) IsFinished() bool {
+	return b.started && len(b.finished) == len(b.entrants)
+}
+
+// 参加費を支払い、スターティングスタックを受け取ってトーナメントに参加する
+func (b *tournamentBook) register(player *entity.Player, capacity int) error {
+	if b.started {
+		return fmt.Errorf("tournament has already started")
+	}
+	if containsPlayer(b.entrants, player) {
+		return fmt.Errorf("player is already registered")
+	}
+	if len(b.entrants) >= capacity {
+		return fmt.Errorf("tournament is full")
+	}
+	if err := b.collect(player, b.config.BuyIn); err != nil {
+		return err
+	}
+	if err := player.AddChips(b.config.StartingStack); err != nil {
+		return err
+	}
+	b.entrants = append(b.entrants, player)
+	b.entries[player] = 1
+	b.nextPosition++
+	return nil
+}
+
+// 所持金からamountを支払わせ、賞金総額に加える
+func (b *tournamentBook) collect(player *entity.Player, amount int) error {
+	cost, err := valueobject.NewMoney(int64(amount), b.config.Currency)
+	if err != nil {
+		return err
+	}
+	prizePool, err := b.prizePool.Add(cost)
+	if err != nil {
+		return err
+	}
+	if err := player.Withdraw(cost); err != nil {
+		return err
+	}
+	b.prizePool = prizePool
+	return nil
+}
+
+// 脱落したプレイヤーに順位をつける。remainingは脱落していないプレイヤー
+// 同じハンドで脱落したプレイヤーは、ハンドの開始時のスタックが多いほうが上位になる
+func (b *tournamentBook) recordEliminations(stacks map[*entity.Player]int, remaining []*entity.Player, handNumber int) error {
+	eliminated := []*entity.Player{}
+	for player := range stacks {
+		if !containsPlayer(remaining, player) {
+			eliminated = append(eliminated, player)
+		}
+	}
+	sort.Slice(eliminated, func(i, j int) bool {
+		return stacks[eliminated[i]] < stacks[eliminated[j]]
+	})
+	for _, player := range eliminated {
+		if err := b.finish(player, b.nextPosition, handNumber); err != nil {
+			return err
+		}
+		b.nextPosition--
+	}
+	if len(remaining) == 1 {
+		return b.finish(remaining[0], 1, 0)
+	}
+	return nil
+}
+
+// 順位を確定し、賞金を所持金に支払う
+func (b *tournamentBook) finish(player *entity.Player, position, handNumber int) error {
+	prize := b.prize(position)
+	if err := player.Deposit(prize); err != nil {
+		return err
+	}
+	b.finished = append(b.finished, Standing{
+		Position:         position,
+		Player:           player,
+		Prize:            prize,
+		EliminatedInHand: handNumber,
+		Entries:          b.entries[player],
+		Rebuys:           b.rebuys[player],
+		AddOns:           b.addOns[player],
+	})
+	return nil
+}
+
+// 順位に応じた賞金。割り切れない端数は上位から最小単位ずつ支払う
+func (b *tournamentBook) prize(position int) valueobject.Money {
+	if position > len(b.config.Payouts) {
+		return valueobject.ZeroMoney(b.config.Currency)
+	}
+	prizes, err := b.prizePool.Allocate(b.config.Payouts)
+	if err != nil {
+		return valueobject.ZeroMoney(b.config.Currency)
+	}
+	return prizes[position-1]
+}
+
+// 順位が決まったプレイヤーを上位から並べる
+func (b *tournamentBook) Standings() []Standing {
+	standings := append([]Standing{}, b.finished...)
+	sort.Slice(standings, func(i, j int) bool {
+		return standings[i].Position < standings[j].Position
+	})
+	return standings
+}
+
+// 最終順位の一覧
+func (b *tournamentBook) Report() string {
+	var sb strings.Builder
+	for _, standing := range b.Standings() {
+		fmt.Fprintf(&sb, "%d. %s prize %s", standing.Position, standing.Player.Name(), standing.Prize)
+		if standing.EliminatedInHand > 0 {
+			fmt.Fprintf(&sb, " (eliminated in hand %d)", standing.EliminatedInHand)
+		}
+		if standing.Entries > 1 {
+			fmt.Fprintf(&sb, " re-entries %d", standing.Entries-1)
+		}
+		if standing.Rebuys > 0 {
+			fmt.Fprintf(&sb, " rebuys %d", standing.Rebuys)
+		}
+		if standing.AddOns > 0 {
+			fmt.Fprintf(&sb, " add-ons %d", standing.AddOns)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ブラインドスケジュールの進行
+type blindClock struct {
+	schedule       []BlindLevel
+	level          int
+	handsInLevel   int
+	levelStartedAt time.Time
+	clock          func() time.Time
+}
+
+func newBlindClock(schedule []BlindLevel) blindClock {
+	return blindClock{schedule: schedule, clock: time.Now}
+}
+
+// 現在のブラインドレベル。最初のレベルは0
+func (c *blindClock) Level() int {
+	return c.level
+}
+
+func (c *blindClock) CurrentBlindLevel() BlindLevel {
+	return c.schedule[c.level]
+}
+
+func (c *blindClock) start() {
+	c.levelStartedAt = c.clock()
+}
+
+// ハンド数か時間が現在のレベルの長さに達していれば次のレベルに進み、現在のブラインドを返す
+func (c *blindClock) advance() Blinds {
+	now := c.clock()
+	for c.level < len(c.schedule)-1 {
+		level := c.schedule[c.level]
+		byHands := level.Hands > 0 && c.handsInLevel >= level.Hands
+		byTime := level.Duration > 0 && now.Sub(c.levelStartedAt) >= level.Duration
+		if !byHands && !byTime {
+			break
+		}
+		c.level++
+		c.handsInLevel = 0
+		c.levelStartedAt = now
+	}
+	return c.schedule[c.level].Blinds
+}
+
+func (c *blindClock) handPlayed() {
+	c.handsInLevel++
+}
+
+// 1つのテーブルで行うトーナメント
+type Tournament struct {
+	*tournamentBook
+	blindClock
+	table   *Table
+	session *Session
+}
+
+func NewTournament(table *Table, config TournamentConfig) (*Tournament, error) {
+	book, err := newTournamentBook(config)
+	if err != nil {
+		return nil, err
+	}
+	// 山札の扱いが指定されていなければ、満席でもドローを続けられるように捨て札をシャッフルし直す
+	if table.deckExhaustion == ErrorOnExhaustion {
+		if err := table.SetDeckExhaustion(ReshuffleMuck); err != nil {
+			return nil, err
+		}
+	}
+	return &Tournament{
+		tournamentBook: book,
+		blindClock:     newBlindClock(config.Schedule),
+		table:          table,
+		session:        NewSession(table),
+	}, nil
+}
+
+func (t *Tournament) Table() *Table {
+	return t.table
+}
+
+func (t *Tournament) SetDecider(decider Decider) {
+	t.session.SetDecider(decider)
+}
+
+func (t *Tournament) Register(player *entity.Player) error {
+	return t.register(player, len(t.table.Seats()))
+}
+
+// 参加者を席に着かせ、最初のブラインドレベルでトーナメントを始める
+func (t *Tournament) Start() error {
+	if t.started {
+		return fmt.Errorf("tournament has already started")
+	}
+	if len(t.entrants) < minSeats {
+		return fmt.Errorf("at least %d players are required", minSeats)
+	}
+	if err := t.table.SetBlinds(t.schedule[0].Blinds); err != nil {
+		return err
+	}
+	for i, player := range t.entrants {
+		if err := t.table.Join(i, player); err != nil {
+			return err
+		}
+	}
+	t.started = true
+	t.start()
+	return nil
+}
+
+// ブラインドレベルを進めてから1ハンドを行い、脱落したプレイヤーの順位を決める
+func (t *Tournament) PlayHand() (HandResult, error) {
+	if !t.started {
+		return HandResult{}, fmt.Errorf("tournament has not started")
+	}
+	if t.IsFinished() {
+		return HandResult{}, fmt.Errorf("tournament is over")
+	}
+	if err := t.table.SetBlinds(t.advance()); err != nil {
+		return HandResult{}, err
+	}
+	stacks := map[*entity.Player]int{}
+	for _, player := range t.table.seatedPlayers() {
+		stacks[player] = player.Stack()
+	}
+	result, err := t.session.PlayHand()
+	if err != nil {
+		return HandResult{}, err
+	}
+	t.handPlayed()
+	if err := t.recordEliminations(stacks, t.table.seatedPlayers(), t.table.HandNumber()); err != nil {
+		return HandResult{}, err
+	}
+	return result, nil
+}
+
+// 優勝者が決まるまでハンドを続け、最終的な順位を返す
+func (t *Tournament) Run() ([]Standing, error) {
+	if !t.started {
+		if err := t.Start(); err != nil {
+			return nil, err
+		}
+	}
+	for !t.IsFinished() {
+		if _, err := t.PlayHand(); err != nil {
+			return nil, err
+		}
+	}
+	return t.Standings(), nil
+}
@@ -0,0 +1,277 @@
+package domainservice
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+)
+
+// 複数のテーブルで行うトーナメントを進行する
+// ハンドの合間に、テーブルの人数の差が1人以内になるようにプレイヤーを移動させ、人数が減ったテーブルを閉じる
+type TournamentDirector struct {
+	*tournamentBook
+	blindClock
+	tableSize int
+	tables    []*Table
+	sessions  map[*Table]*Session
+	decider   Decider
+	// 行ったハンドの数。すべてのテーブルで1ハンドずつ行うと1進む
+	round int
+	// これまでに用意したテーブルの数。テーブル名の番号に使う
+	openedTables int
+}
+
+func NewTournamentDirector(config TournamentConfig, tableSize int) (*TournamentDirector, error) {
+	if tableSize < minSeats || tableSize > maxSeats {
+		return nil, fmt.Errorf("table size must be between %d and %d", minSeats, maxSeats)
+	}
+	book, err := newTournamentBook(config)
+	if err != nil {
+		return nil, err
+	}
+	return &TournamentDirector{
+		tournamentBook: book,
+		blindClock:     newBlindClock(config.Schedule),
+		tableSize:      tableSize,
+		sessions:       map[*Table]*Session{},
+		decider:        PassiveDecider{},
+	}, nil
+}
+
+func (d *TournamentDirector) SetDecider(decider Decider) {
+	d.decider = decider
+	for _, session := range d.sessions {
+		session.SetDecider(decider)
+	}
+}
+
+// プレイ中のテーブル
+func (d *TournamentDirector) Tables() []*Table {
+	return d.tables
+}
+
+// プレイヤーが座っているテーブル
+func (d *TournamentDirector) TableOf(player *entity.Player) (*Table, error) {
+	for _, table := range d.tables {
+		if _, err := table.SeatOf(player); err == nil {
+			return table, nil
+		}
+	}
+	return nil, fmt.Errorf("player is not seated")
+}
+
+func (d *TournamentDirector) Register(player *entity.Player) error {
+	return d.register(player, len(d.entrants)+1)
+}
+
+// 必要な数のテーブルを用意し、参加者をランダムな席に割り当ててトーナメントを始める
+func (d *TournamentDirector) Start() error {
+	if d.started {
+		return fmt.Errorf("tournament has already started")
+	}
+	if len(d.entrants) < minSeats {
+		return fmt.Errorf("at least %d players are required", minSeats)
+	}
+	numberOfTables := (len(d.entrants) + d.tableSize - 1) / d.tableSize
+	for i := 0; i < numberOfTables; i++ {
+		if _, err := d.openTable(); err != nil {
+			return err
+		}
+	}
+	entrants := append([]*entity.Player{}, d.entrants...)
+	rand.Shuffle(len(entrants), func(i, j int) {
+		entrants[i], entrants[j] = entrants[j], entrants[i]
+	})
+	for i, player := range entrants {
+		table := d.tables[i%numberOfTables]
+		if err := table.Join(randomEmptySeat(table), player); err != nil {
+			return err
+		}
+	}
+	d.started = true
+	d.start()
+	return nil
+}
+
+// すべてのテーブルで1ハンドずつ行い、脱落したプレイヤーの順位を決めてからテーブルを調整する
+func (d *TournamentDirector) PlayRound() error {
+	if !d.started {
+		return fmt.Errorf("tournament has not started")
+	}
+	if d.IsFinished() {
+		return fmt.Errorf("tournament is over")
+	}
+	blinds := d.advance()
+	stacks := map[*entity.Player]int{}
+	for _, table := range d.tables {
+		if err := table.SetBlinds(blinds); err != nil {
+			return err
+		}
+		for _, player := range table.seatedPlayers() {
+			stacks[player] = player.Stack()
+		}
+	}
+	d.round++
+	for _, table := range d.tables {
+		session := d.sessions[table]
+		if session.ShouldStop() {
+			continue
+		}
+		if _, err := session.PlayHand(); err != nil {
+			return err
+		}
+	}
+	d.handPlayed()
+	if err := d.recordEliminations(stacks, d.remainingPlayers(), d.round); err != nil {
+		return err
+	}
+	if d.IsFinished() {
+		return nil
+	}
+	if err := d.breakTables(); err != nil {
+		return err
+	}
+	return d.balanceTables()
+}
+
+// 優勝者が決まるまでハンドを続け、最終的な順位を返す
+func (d *TournamentDirector) Run() ([]Standing, error) {
+	if !d.started {
+		if err := d.Start(); err != nil {
+			return nil, err
+		}
+	}
+	for !d.IsFinished() {
+		if err := d.PlayRound(); err != nil {
+			return nil, err
+		}
+	}
+	return d.Standings(), nil
+}
+
+// 現在のブラインドで新しいテーブルを用意する
+func (d *TournamentDirector) openTable() (*Table, error) {
+	d.openedTables++
+	table, err := NewTableWithSeats(fmt.Sprintf("table-%d", d.openedTables), d.tableSize)
+	if err != nil {
+		return nil, err
+	}
+	// 9人や10人のテーブルではドローの途中で山札が足りなくなるため、捨て札をシャッフルし直してハンドを続ける
+	if err := table.SetDeckExhaustion(ReshuffleMuck); err != nil {
+		return nil, err
+	}
+	if err := table.SetBlinds(d.CurrentBlindLevel().Blinds); err != nil {
+		return nil, err
+	}
+	session := NewSession(table)
+	session.SetDecider(d.decider)
+	d.tables = append(d.tables, table)
+	d.sessions[table] = session
+	return table, nil
+}
+
+func (d *TournamentDirector) remainingPlayers() []*entity.Player {
+	players := []*entity.Player{}
+	for _, table := range d.tables {
+		players = append(players, table.seatedPlayers()...)
+	}
+	return players
+}
+
+// 残りのプレイヤーが1つ少ないテーブル数に収まる間、最も人数の少ないテーブルを閉じて他のテーブルに移す
+// 最後は1つのファイナルテーブルにまとまる
+func (d *TournamentDirector) breakTables() error {
+	for len(d.tables) > 1 && len(d.remainingPlayers()) <= (len(d.tables)-1)*d.tableSize {
+		broken := d.smallestTable()
+		d.removeTable(broken)
+		for _, player := range broken.seatedPlayers() {
+			if err := d.movePlayer(player, broken, d.smallestTable()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 最も多いテーブルと最も少ないテーブルの人数の差が1人以内になるまでプレイヤーを移動させる
+func (d *TournamentDirector) balanceTables() error {
+	for len(d.tables) > 1 {
+		largest, smallest := d.largestTable(), d.smallestTable()
+		if len(largest.seatedPlayers())-len(smallest.seatedPlayers()) <= 1 {
+			return nil
+		}
+		player := nextBigBlindPlayer(largest)
+		if err := d.movePlayer(player, largest, smallest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 次のハンドでビッグブラインドを支払うプレイヤー。ブラインドを支払わずに抜けることがないよう、このプレイヤーを移動させる
+func nextBigBlindPlayer(table *Table) *entity.Player {
+	seat := table.nextSeat(table.bigBlindSeat, func(seat int) bool {
+		return !table.seats[seat].IsEmpty()
+	})
+	return table.seats[seat].player
+}
+
+// プレイヤーを移動先のテーブルの、次にビッグブラインドが回ってくる空席に座らせる
+func (d *TournamentDirector) movePlayer(player *entity.Player, from, to *Table) error {
+	if err := from.Leave(player); err != nil {
+		return err
+	}
+	seat := to.nextSeat(to.bigBlindSeat, func(seat int) bool {
+		return to.seats[seat].IsEmpty()
+	})
+	if to.bigBlindSeat < 0 {
+		seat = randomEmptySeat(to)
+	}
+	return to.Join(seat, player)
+}
+
+// 空席の中からランダムに1つ選ぶ。空席がなければ-1
+func randomEmptySeat(table *Table) int {
+	empty := []int{}
+	for i, seat := range table.seats {
+		if seat.IsEmpty() {
+			empty = append(empty, i)
+		}
+	}
+	if len(empty) == 0 {
+		return -1
+	}
+	return empty[rand.Intn(len(empty))]
+}
+
+func (d *TournamentDirector) largestTable() *Table {
+	largest := d.tables[0]
+	for _, table := range d.tables[1:] {
+		if len(table.seatedPlayers()) > len(largest.seatedPlayers()) {
+			largest = table
+		}
+	}
+	return largest
+}
+
+// 最も人数の少ないテーブル
+func (d *TournamentDirector) smallestTable() *Table {
+	var smallest *Table
+	for _, table := range d.tables {
+		if smallest == nil || len(table.seatedPlayers()) < len(smallest.seatedPlayers()) {
+			smallest = table
+		}
+	}
+	return smallest
+}
+
+func (d *TournamentDirector) removeTable(table *Table) {
+	for i, t := range d.tables {
+		if t == table {
+			d.tables = append(d.tables[:i], d.tables[i+1:]...)
+			break
+		}
+	}
+	delete(d.sessions, table)
+}
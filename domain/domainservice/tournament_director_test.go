@@ -0,0 +1,192 @@
+package domainservice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func newTestTournamentDirector(t *testing.T, numberOfPlayers, tableSize int) *TournamentDirector {
+	t.Helper()
+	director, err := NewTournamentDirector(TournamentConfig{
+		BuyIn:         100,
+		StartingStack: 1000,
+		Schedule:      testSchedule,
+		Payouts:       []int{5000, 3000, 2000},
+	}, tableSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < numberOfPlayers; i++ {
+		if err := director.Register(newTestPlayer(t, fmt.Sprintf("player%d", i), 1000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return director
+}
+
+func tableSizes(director *TournamentDirector) []int {
+	sizes := []int{}
+	for _, table := range director.Tables() {
+		sizes = append(sizes, len(table.seatedPlayers()))
+	}
+	return sizes
+}
+
+func assertBalanced(t *testing.T, director *TournamentDirector) {
+	t.Helper()
+	sizes := tableSizes(director)
+	minimum, maximum := sizes[0], sizes[0]
+	for _, size := range sizes {
+		minimum, maximum = min(minimum, size), max(maximum, size)
+	}
+	if maximum-minimum > 1 {
+		t.Errorf("table sizes = %v, want differences of at most 1", sizes)
+	}
+}
+
+func TestTournamentDirector_Start(t *testing.T) {
+	director := newTestTournamentDirector(t, 23, 9)
+	if err := director.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if len(director.Tables()) != 3 {
+		t.Fatalf("len(Tables()) = %v, want 3", len(director.Tables()))
+	}
+	assertBalanced(t, director)
+	for _, player := range director.Entrants() {
+		if _, err := director.TableOf(player); err != nil {
+			t.Errorf("player %s is not seated", player.Name())
+		}
+	}
+	if got := len(director.remainingPlayers()); got != 23 {
+		t.Errorf("seated players = %v, want 23", got)
+	}
+}
+
+func TestTournamentDirector_balanceTables(t *testing.T) {
+	director := newTestTournamentDirector(t, 18, 6)
+	if err := director.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := director.PlayRound(); err != nil {
+		t.Fatal(err)
+	}
+	// 1つのテーブルから3人が抜けた状態を作る
+	table := director.Tables()[0]
+	for _, player := range table.seatedPlayers()[:3] {
+		if err := table.Leave(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := director.balanceTables(); err != nil {
+		t.Fatal(err)
+	}
+	assertBalanced(t, director)
+	for _, table := range director.Tables() {
+		if _, err := NewSession(table).PlayHand(); err != nil {
+			t.Errorf("Session.PlayHand() after balancing error = %v", err)
+		}
+	}
+}
+
+func TestTournamentDirector_breakTables(t *testing.T) {
+	director := newTestTournamentDirector(t, 18, 6)
+	if err := director.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// 残りが12人になれば2つのテーブルに収まる
+	for _, table := range director.Tables() {
+		for _, player := range table.seatedPlayers()[:2] {
+			if err := table.Leave(player); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := director.breakTables(); err != nil {
+		t.Fatal(err)
+	}
+	if len(director.Tables()) != 2 {
+		t.Fatalf("len(Tables()) = %v, want 2", len(director.Tables()))
+	}
+	if got := len(director.remainingPlayers()); got != 12 {
+		t.Errorf("seated players = %v, want 12", got)
+	}
+	assertBalanced(t, director)
+}
+
+func TestNextBigBlindPlayer(t *testing.T) {
+	table, players := newSeatTestTable(t, 6, []int{0, 1, 3, 4})
+	if _, err := NewSession(table).PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	// ボタンは席0、ビッグブラインドは席3なので、次は席4
+	if got := nextBigBlindPlayer(table); got != players[4] {
+		t.Errorf("nextBigBlindPlayer() = %v, want players[4]", got.Name())
+	}
+}
+
+func TestTournamentDirector_Run(t *testing.T) {
+	director := newTestTournamentDirector(t, 20, 6)
+	director.SetDecider(allInDecider{})
+	standings, err := director.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(standings) != 20 {
+		t.Fatalf("len(standings) = %v, want 20", len(standings))
+	}
+	prizes := int64(0)
+	for i, standing := range standings {
+		if standing.Position != i+1 {
+			t.Errorf("standings[%d].Position = %v, want %v", i, standing.Position, i+1)
+		}
+		prizes += standing.Prize.Amount()
+	}
+	if prizes != director.PrizePool().Amount() {
+		t.Errorf("total prizes = %v, want %v", prizes, director.PrizePool())
+	}
+	if len(director.Tables()) != 1 {
+		t.Errorf("len(Tables()) = %v, want 1 final table", len(director.Tables()))
+	}
+	if standings[0].Player.Stack() != 20000 {
+		t.Errorf("winner stack = %v, want 20000", standings[0].Player.Stack())
+	}
+}
+
+// ドロー前はチェックかコールだけを行い、手札の2枚を交換して、ドロー後はオールインする
+type drawingAllInDecider struct{}
+
+func (drawingAllInDecider) DecideAction(t *Table, player *entity.Player) Action {
+	if t.BettingRound() == 1 {
+		return PassiveDecider{}.DecideAction(t, player)
+	}
+	return allInDecider{}.DecideAction(t, player)
+}
+
+func (drawingAllInDecider) DecideDiscards(t *Table, player *entity.Player) []*valueobject.Card {
+	return append([]*valueobject.Card{}, player.Cards()[:2]...)
+}
+
+func TestTournamentDirector_Run_TenHanded(t *testing.T) {
+	// 10人が2枚ずつ交換すると、配った後に残る2枚では足りない
+	director := newTestTournamentDirector(t, 10, 10)
+	director.SetDecider(drawingAllInDecider{})
+	standings, err := director.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(standings) != 10 {
+		t.Fatalf("len(standings) = %v, want 10", len(standings))
+	}
+	for i, standing := range standings {
+		if standing.Position != i+1 {
+			t.Errorf("standings[%d].Position = %v, want %v", i, standing.Position, i+1)
+		}
+	}
+	if standings[0].Player.Stack() != 10000 {
+		t.Errorf("winner stack = %v, want 10000", standings[0].Player.Stack())
+	}
+}
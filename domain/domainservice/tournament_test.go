@@ -0,0 +1,204 @@
+package domainservice
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+var testSchedule = []BlindLevel{
+	{Blinds: Blinds{SmallBlind: 5, BigBlind: 10}, Hands: 2},
+	{Blinds: Blinds{SmallBlind: 10, BigBlind: 20}, Hands: 2},
+	{Blinds: Blinds{SmallBlind: 25, BigBlind: 50, Ante: 50, AnteType: BigBlindAnte}},
+}
+
+// numberOfPlayers人が参加したトーナメントを作る。各プレイヤーの所持金は1000
+func newTestTournament(t *testing.T, numberOfPlayers int, config TournamentConfig) (*Tournament, []*entity.Player) {
+	t.Helper()
+	table, err := NewTableWithSeats("table", maxSeats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tournament, err := NewTournament(table, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	players := []*entity.Player{}
+	for i := 0; i < numberOfPlayers; i++ {
+		player := newTestPlayer(t, fmt.Sprintf("player%d", i), 1000)
+		if err := tournament.Register(player); err != nil {
+			t.Fatal(err)
+		}
+		players = append(players, player)
+	}
+	return tournament, players
+}
+
+func TestNewTournament(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TournamentConfig
+		wantErr bool
+	}{
+		{
+			name:    "有効な設定",
+			config:  TournamentConfig{BuyIn: 100, StartingStack: 1500, Schedule: testSchedule, Payouts: []int{6500, 3500}},
+			wantErr: false,
+		},
+		{
+			name:    "ブラインドスケジュールがない",
+			config:  TournamentConfig{BuyIn: 100, StartingStack: 1500, Payouts: []int{10000}},
+			wantErr: true,
+		},
+		{
+			name:    "賞金の割合の合計が100%でない",
+			config:  TournamentConfig{BuyIn: 100, StartingStack: 1500, Schedule: testSchedule, Payouts: []int{5000, 3000}},
+			wantErr: true,
+		},
+		{
+			name:    "スターティングスタックが0",
+			config:  TournamentConfig{BuyIn: 100, Schedule: testSchedule, Payouts: []int{10000}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := NewTableWithSeats("table", 6)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewTournament(table, tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("NewTournament() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTournament_Register(t *testing.T) {
+	tournament, players := newTestTournament(t, 2, TournamentConfig{BuyIn: 100, StartingStack: 1500, Schedule: testSchedule, Payouts: []int{10000}})
+	if players[0].Money().Amount() != 900 || players[0].Stack() != 1500 {
+		t.Errorf("money = %v, stack = %v, want 900, 1500", players[0].Money(), players[0].Stack())
+	}
+	if tournament.PrizePool().Amount() != 200 {
+		t.Errorf("Tournament.PrizePool() = %v, want 200", tournament.PrizePool())
+	}
+	if err := tournament.Register(players[0]); err == nil {
+		t.Errorf("Tournament.Register() twice error = nil, want error")
+	}
+	if err := tournament.Register(newTestPlayer(t, "poor", 50)); err == nil {
+		t.Errorf("Tournament.Register() without enough money error = nil, want error")
+	}
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tournament.Register(newTestPlayer(t, "late", 1000)); err == nil {
+		t.Errorf("Tournament.Register() after start error = nil, want error")
+	}
+}
+
+func TestTournament_BlindLevels(t *testing.T) {
+	tournament, _ := newTestTournament(t, 3, TournamentConfig{BuyIn: 100, StartingStack: 5000, Schedule: testSchedule, Payouts: []int{10000}})
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	wantLevels := []int{0, 0, 1, 1, 2, 2, 2}
+	for i, want := range wantLevels {
+		if _, err := tournament.PlayHand(); err != nil {
+			t.Fatal(err)
+		}
+		if got := tournament.Level(); got != want {
+			t.Errorf("hand %d: Tournament.Level() = %v, want %v", i+1, got, want)
+		}
+		if got := tournament.Table().Blinds(); got != testSchedule[want].Blinds {
+			t.Errorf("hand %d: Table.Blinds() = %+v, want %+v", i+1, got, testSchedule[want].Blinds)
+		}
+	}
+}
+
+func TestTournament_BlindLevelsByTime(t *testing.T) {
+	schedule := []BlindLevel{
+		{Blinds: Blinds{SmallBlind: 5, BigBlind: 10}, Duration: 15 * time.Minute},
+		{Blinds: Blinds{SmallBlind: 10, BigBlind: 20}, Duration: 15 * time.Minute},
+	}
+	tournament, _ := newTestTournament(t, 3, TournamentConfig{BuyIn: 100, StartingStack: 5000, Schedule: schedule, Payouts: []int{10000}})
+	now := time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC)
+	tournament.clock = func() time.Time { return now }
+	if err := tournament.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tournament.PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(14 * time.Minute)
+	if _, err := tournament.PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	if tournament.Level() != 0 {
+		t.Errorf("Tournament.Level() = %v, want 0", tournament.Level())
+	}
+	now = now.Add(time.Minute)
+	if _, err := tournament.PlayHand(); err != nil {
+		t.Fatal(err)
+	}
+	if tournament.Level() != 1 {
+		t.Errorf("Tournament.Level() = %v, want 1", tournament.Level())
+	}
+}
+
+func TestTournament_Run(t *testing.T) {
+	tournament, players := newTestTournament(t, 4, TournamentConfig{BuyIn: 100, StartingStack: 1000, Schedule: testSchedule, Payouts: []int{6500, 3500}})
+	tournament.SetDecider(allInDecider{})
+	standings, err := tournament.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(standings) != len(players) {
+		t.Fatalf("len(standings) = %v, want %v", len(standings), len(players))
+	}
+	wantPrizes := []int{260, 140, 0, 0}
+	seen := map[*entity.Player]bool{}
+	for i, standing := range standings {
+		if standing.Position != i+1 {
+			t.Errorf("standings[%d].Position = %v, want %v", i, standing.Position, i+1)
+		}
+		if standing.Prize.Amount() != int64(wantPrizes[i]) {
+			t.Errorf("standings[%d].Prize = %v, want %v", i, standing.Prize, wantPrizes[i])
+		}
+		if standing.Player.Money().Amount() != int64(900+wantPrizes[i]) {
+			t.Errorf("standings[%d].Player.Money() = %v, want %v", i, standing.Player.Money(), 900+wantPrizes[i])
+		}
+		if (standing.EliminatedInHand == 0) != (i == 0) {
+			t.Errorf("standings[%d].EliminatedInHand = %v", i, standing.EliminatedInHand)
+		}
+		seen[standing.Player] = true
+	}
+	if len(seen) != len(players) {
+		t.Errorf("standings contain duplicated players")
+	}
+	if standings[0].Player.Stack() != 4000 {
+		t.Errorf("winner stack = %v, want 4000", standings[0].Player.Stack())
+	}
+	if !strings.HasPrefix(tournament.Report(), "1. "+standings[0].Player.Name()+" prize ¥260\n") {
+		t.Errorf("Tournament.Report() = %q", tournament.Report())
+	}
+	if _, err := tournament.PlayHand(); err == nil {
+		t.Errorf("Tournament.PlayHand() after the end error = nil, want error")
+	}
+}
+
+func TestTournament_prize(t *testing.T) {
+	tournament, _ := newTestTournament(t, 3, TournamentConfig{BuyIn: 10, StartingStack: 1000, Schedule: testSchedule, Payouts: []int{5000, 3000, 2000}})
+	// 賞金総額31を分けたときの端数は上位から支払う
+	tournament.prizePool, _ = valueobject.NewMoney(31, valueobject.DefaultCurrency)
+	got := []int64{tournament.prize(1).Amount(), tournament.prize(2).Amount(), tournament.prize(3).Amount(), tournament.prize(4).Amount()}
+	want := []int64{16, 9, 6, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Tournament.prize(%d) = %v, want %v", i+1, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package domainservice
+
+import (
+	"fmt"
+
+	"github.com/KoheiMatsuno99/poker/domain/entity"
+	"github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// 1人用のビデオポーカー
+type VideoPoker struct {
+	payTable *PayTable
+	player   *entity.Player
+	deck     []*valueobject.Card
+	hand     []*valueobject.Card
+	coins    int
+	// 配られてからまだ交換していない状態かどうか
+	dealt bool
+}
+
+func NewVideoPoker(payTable *PayTable, player *entity.Player) *VideoPoker {
+	return &VideoPoker{
+		payTable: payTable,
+		player:   player,
+	}
+}
+
+func (v *VideoPoker) PayTable() *PayTable {
+	return v.payTable
+}
+
+func (v *VideoPoker) Hand() []*valueobject.Card {
+	return v.hand
+}
+
+// coins枚を賭けて5枚配る
+func (v *VideoPoker) Deal(coins int) ([]*valueobject.Card, error) {
+	if v.dealt {
+		return nil, fmt.Errorf("hand is already dealt")
+	}
+	if coins <= 0 {
+		return nil, fmt.Errorf("coins must be positive")
+	}
+	cost, err := v.coinValue().Multiply(int64(coins))
+	if err != nil {
+		return nil, err
+	}
+	if err := v.player.Withdraw(cost); err != nil {
+		return nil, err
+	}
+	v.deck = shuffleDeck(createDeck())
+	v.hand = append([]*valueobject.Card{}, v.deck[:numberOfHandCards]...)
+	v.deck = v.deck[numberOfHandCards:]
+	v.coins = coins
+	v.dealt = true
+	return v.hand, nil
+}
+
+// holdで指定した位置のカードを残して残りを交換し、配当を支払う
+func (v *VideoPoker) Draw(hold []bool) ([]*valueobject.Card, int, error) {
+	if !v.dealt {
+		return nil, 0, fmt.Errorf("hand is not dealt")
+	}
+	if len(hold) != numberOfHandCards {
+		return nil, 0, fmt.Errorf("hold must have %d elements", numberOfHandCards)
+	}
+	for i, held := range hold {
+		if !held {
+			v.hand[i] = v.deck[0]
+			v.deck = v.deck[1:]
+		}
+	}
+	v.dealt = false
+	payout, err := v.payTable.Payout(v.hand)
+	if err != nil {
+		return nil, 0, err
+	}
+	winnings := payout * v.coins
+	amount, err := v.coinValue().Multiply(int64(winnings))
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := v.player.Deposit(amount); err != nil {
+		return nil, 0, err
+	}
+	return v.hand, winnings, nil
+}
+
+// コイン1枚の金額。プレイヤーの所持金の通貨の最小単位1つ分
+func (v *VideoPoker) coinValue() valueobject.Money {
+	return valueobject.MinorUnit(v.player.Money().Currency())
+}
@@ -0,0 +1,48 @@
+package domainservice
+
+import "testing"
+
+func TestVideoPoker_DealAndDraw(t *testing.T) {
+	player := newTestPlayer(t, "player", 100)
+	v := NewVideoPoker(JacksOrBetterPayTable(), player)
+	if _, _, err := v.Draw([]bool{true, true, true, true, true}); err == nil {
+		t.Fatalf("VideoPoker.Draw() before deal error = nil, want error")
+	}
+	hand, err := v.Deal(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hand) != 5 {
+		t.Fatalf("len(hand) = %v, want 5", len(hand))
+	}
+	if player.Money().Amount() != 95 {
+		t.Errorf("player.Money() = %v, want 95", player.Money())
+	}
+	if _, err := v.Deal(5); err == nil {
+		t.Errorf("VideoPoker.Deal() twice error = nil, want error")
+	}
+	payout, err := v.PayTable().Payout(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	final, winnings, err := v.Draw([]bool{true, true, true, true, true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(final) != 5 {
+		t.Errorf("len(final) = %v, want 5", len(final))
+	}
+	if winnings != payout*5 {
+		t.Errorf("winnings = %v, want %v", winnings, payout*5)
+	}
+	if player.Money().Amount() != int64(95+winnings) {
+		t.Errorf("player.Money() = %v, want %v", player.Money(), 95+winnings)
+	}
+}
+
+func TestVideoPoker_Deal_NotEnoughMoney(t *testing.T) {
+	v := NewVideoPoker(JacksOrBetterPayTable(), newTestPlayer(t, "player", 3))
+	if _, err := v.Deal(5); err == nil {
+		t.Errorf("VideoPoker.Deal() error = nil, want error")
+	}
+}
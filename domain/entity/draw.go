@@ -0,0 +1,232 @@
+package entity
+
+import (
+	valueobject "github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+const (
+	FlushDraw            = "フラッシュドロー"
+	OpenEndedDraw        = "オープンエンドストレートドロー"
+	GutshotDraw          = "ガットショットストレートドロー"
+	BackdoorFlushDraw    = "バックドアフラッシュドロー"
+	BackdoorStraightDraw = "バックドアストレートドロー"
+	OvercardsDraw        = "オーバーカード"
+)
+
+const (
+	straightLength = 5
+	// A, 2, 3, 4, 5のストレートの最も高いランク
+	lowestStraightHigh = 5
+)
+
+// ドローの種類と、その役を完成させるカード (アウツ)
+type Draw struct {
+	Kind string
+	Outs []*valueobject.Card
+}
+
+// 手札とボードからドローを検出する
+// deadには既に見えているカードを渡し、アウツから除外する。ボードのないファイブカードドローではboardを空にする
+func DetectDraws(hole []*valueobject.Card, board []*valueobject.Card, dead []*valueobject.Card) []Draw {
+	known := append(append([]*valueobject.Card{}, hole...), board...)
+	live := []*valueobject.Card{}
+	for _, card := range valueobject.FullDeck() {
+		if !valueobject.ContainsCard(known, card) && !valueobject.ContainsCard(dead, card) {
+			live = append(live, card)
+		}
+	}
+	draws := []Draw{}
+	if !hasFlush(known) {
+		if outs := completingCards(known, live, hasFlush); len(outs) > 0 {
+			draws = append(draws, Draw{Kind: FlushDraw, Outs: outs})
+		} else if suit, ok := backdoorFlushSuit(known); ok {
+			draws = append(draws, Draw{Kind: BackdoorFlushDraw, Outs: cardsOfSuit(live, suit)})
+		}
+	}
+	if !hasStraight(known) {
+		// 間の空いたダブルガットショットもアウツは8枚になるため、種類はアウツの数ではなく4枚が連続しているかで決める
+		outs := completingCards(known, live, hasStraight)
+		switch {
+		case len(outs) > 0 && hasOpenEndedRun(rankSet(known)):
+			draws = append(draws, Draw{Kind: OpenEndedDraw, Outs: outs})
+		case len(outs) > 0:
+			draws = append(draws, Draw{Kind: GutshotDraw, Outs: outs})
+		default:
+			if outs := backdoorStraightCards(known, live); len(outs) > 0 {
+				draws = append(draws, Draw{Kind: BackdoorStraightDraw, Outs: outs})
+			}
+		}
+	}
+	if outs := overcardOuts(hole, board, live); len(outs) > 0 {
+		draws = append(draws, Draw{Kind: OvercardsDraw, Outs: outs})
+	}
+	return draws
+}
+
+// 複数のドローのアウツを重複なしでまとめる
+func UniqueOuts(draws []Draw) []*valueobject.Card {
+	outs := []*valueobject.Card{}
+	for _, draw := range draws {
+		for _, card := range draw.Outs {
+			if !valueobject.ContainsCard(outs, card) {
+				outs = append(outs, card)
+			}
+		}
+	}
+	return outs
+}
+
+// 1枚加えることでcompleteを満たすようになるカードを返す
+func completingCards(known []*valueobject.Card, live []*valueobject.Card, complete func([]*valueobject.Card) bool) []*valueobject.Card {
+	outs := []*valueobject.Card{}
+	for _, card := range live {
+		if complete(append(append([]*valueobject.Card{}, known...), card)) {
+			outs = append(outs, card)
+		}
+	}
+	return outs
+}
+
+// 同じスートが5枚以上あるかどうか
+func hasFlush(cards []*valueobject.Card) bool {
+	for _, count := range suitCounts(cards) {
+		if count >= numberOfCards {
+			return true
+		}
+	}
+	return false
+}
+
+// 5枚連続するランクがあるかどうか
+func hasStraight(cards []*valueobject.Card) bool {
+	ranks := rankSet(cards)
+	for high := lowestStraightHigh; high <= valueobject.ValueRankMap()["A"]; high++ {
+		if countInWindow(ranks, high) == straightLength {
+			return true
+		}
+	}
+	return false
+}
+
+func suitCounts(cards []*valueobject.Card) map[string]int {
+	counts := map[string]int{}
+	for _, card := range cards {
+		counts[card.Suit()]++
+	}
+	return counts
+}
+
+// カードのランクの集合。Aは1としても数える
+func rankSet(cards []*valueobject.Card) map[int]bool {
+	ranks := map[int]bool{}
+	for _, card := range cards {
+		rank := valueobject.ValueRankMap()[card.Value()]
+		ranks[rank] = true
+		if card.Value() == "A" {
+			ranks[1] = true
+		}
+	}
+	return ranks
+}
+
+// highを最も高いランクとする5枚の範囲に含まれるランクの数
+func countInWindow(ranks map[int]bool, high int) int {
+	count := 0
+	for rank := high - straightLength + 1; rank <= high; rank++ {
+		if ranks[rank] {
+			count++
+		}
+	}
+	return count
+}
+
+// 両端のどちらに1枚加えてもストレートになる、4枚連続するランクがあるかどうか
+// A, 2, 3, 4やJ, Q, K, Aは片側にしか伸ばせないので含めない
+func hasOpenEndedRun(ranks map[int]bool) bool {
+	for low := 2; low+straightLength-1 <= valueobject.ValueRankMap()["A"]; low++ {
+		if ranks[low] && ranks[low+1] && ranks[low+2] && ranks[low+3] {
+			return true
+		}
+	}
+	return false
+}
+
+func backdoorFlushSuit(cards []*valueobject.Card) (string, bool) {
+	for suit, count := range suitCounts(cards) {
+		if count == numberOfCards-2 {
+			return suit, true
+		}
+	}
+	return "", false
+}
+
+func cardsOfSuit(cards []*valueobject.Card, suit string) []*valueobject.Card {
+	result := []*valueobject.Card{}
+	for _, card := range cards {
+		if card.Suit() == suit {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+// 2枚加えることでストレートになるとき、その1枚目になりうるカードを返す
+func backdoorStraightCards(known []*valueobject.Card, live []*valueobject.Card) []*valueobject.Card {
+	ranks := rankSet(known)
+	outRanks := map[int]bool{}
+	for high := lowestStraightHigh; high <= valueobject.ValueRankMap()["A"]; high++ {
+		if countInWindow(ranks, high) != straightLength-2 {
+			continue
+		}
+		for rank := high - straightLength + 1; rank <= high; rank++ {
+			if !ranks[rank] {
+				outRanks[rank] = true
+			}
+		}
+	}
+	outs := []*valueobject.Card{}
+	for _, card := range live {
+		rank := valueobject.ValueRankMap()[card.Value()]
+		if outRanks[rank] || (card.Value() == "A" && outRanks[1]) {
+			outs = append(outs, card)
+		}
+	}
+	return outs
+}
+
+// ボードのどのカードよりも高い手札のカードがペアになるカードを返す
+// ボードがない場合、または手札が既にペアを作っている場合は空を返す
+func overcardOuts(hole []*valueobject.Card, board []*valueobject.Card, live []*valueobject.Card) []*valueobject.Card {
+	if len(board) == 0 {
+		return nil
+	}
+	highestBoard := 0
+	boardRanks := map[string]bool{}
+	for _, card := range board {
+		boardRanks[card.Value()] = true
+		if rank := valueobject.ValueRankMap()[card.Value()]; rank > highestBoard {
+			highestBoard = rank
+		}
+	}
+	overcards := map[string]bool{}
+	for i, card := range hole {
+		if boardRanks[card.Value()] {
+			return nil
+		}
+		for _, other := range hole[i+1:] {
+			if other.Value() == card.Value() {
+				return nil
+			}
+		}
+		if valueobject.ValueRankMap()[card.Value()] > highestBoard {
+			overcards[card.Value()] = true
+		}
+	}
+	outs := []*valueobject.Card{}
+	for _, card := range live {
+		if overcards[card.Value()] {
+			outs = append(outs, card)
+		}
+	}
+	return outs
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectDraws(t *testing.T) {
+	tests := []struct {
+		name  string
+		hole  string
+		board string
+		dead  string
+		// ドローの種類ごとのアウツの数
+		want map[string]int
+	}{
+		{
+			name:  "フラッシュドローとオーバーカード",
+			hole:  "AsKs",
+			board: "7s2s9h",
+			dead:  "",
+			want:  map[string]int{FlushDraw: 9, OvercardsDraw: 6},
+		},
+		{
+			name:  "見えているカードはアウツから除く",
+			hole:  "AsKs",
+			board: "7s2s9h",
+			dead:  "3sAh",
+			want:  map[string]int{FlushDraw: 8, OvercardsDraw: 5},
+		},
+		{
+			name:  "オープンエンドストレートドロー",
+			hole:  "8h9d",
+			board: "7c6s2h",
+			dead:  "",
+			want:  map[string]int{OpenEndedDraw: 8, OvercardsDraw: 6},
+		},
+		{
+			name:  "ガットショットストレートドロー",
+			hole:  "8h9d",
+			board: "5c6s2h",
+			dead:  "",
+			want:  map[string]int{GutshotDraw: 4, OvercardsDraw: 6},
+		},
+		{
+			name:  "ダブルガットショットはアウツが8枚でもガットショット",
+			hole:  "5h7d8c9sJh",
+			board: "",
+			dead:  "",
+			want:  map[string]int{GutshotDraw: 8},
+		},
+		{
+			name:  "バックドアドロー",
+			hole:  "JhTh",
+			board: "9h3c2d",
+			dead:  "",
+			want:  map[string]int{BackdoorFlushDraw: 10, BackdoorStraightDraw: 16, OvercardsDraw: 6},
+		},
+		{
+			name:  "ファイブカードドローのストレートフラッシュドロー",
+			hole:  "2h3h4h5h9c",
+			board: "",
+			dead:  "",
+			want:  map[string]int{FlushDraw: 9, OpenEndedDraw: 8},
+		},
+		{
+			name:  "完成したフラッシュはドローではない",
+			hole:  "2h5h8hJhKh",
+			board: "",
+			dead:  "",
+			want:  map[string]int{},
+		},
+		{
+			name:  "ペアがあるときはオーバーカードではない",
+			hole:  "AsKd",
+			board: "Kh7c2s",
+			dead:  "",
+			want:  map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draws := DetectDraws(mustParseCards(t, tt.hole), mustParseCards(t, tt.board), mustParseCards(t, tt.dead))
+			got := map[string]int{}
+			for _, draw := range draws {
+				got[draw.Kind] = len(draw.Outs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectDraws() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueOuts(t *testing.T) {
+	// フラッシュドローとオープンエンドが2h, 6hを共有する
+	draws := DetectDraws(mustParseCards(t, "2h3h4h5h9c"), nil, nil)
+	if got := len(UniqueOuts(draws)); got != 15 {
+		t.Errorf("len(UniqueOuts()) = %v, want 15", got)
+	}
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+
+	valueobject "github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+// 役と比較用のランク列をまとめた、比較可能なハンドの強さ
+type HandValue struct {
+	hand  string
+	ranks []int
+}
+
+func (h HandValue) Hand() string {
+	return h.hand
+}
+
+// 比較用のランク列。枚数の多い組、ランクの高い順に並ぶ
+func (h HandValue) Ranks() []int {
+	return h.ranks
+}
+
+// hがotherより強ければ正、弱ければ負、引き分けなら0を返す
+func (h HandValue) Compare(other HandValue) int {
+	if handRankMap[h.hand] != handRankMap[other.hand] {
+		return handRankMap[h.hand] - handRankMap[other.hand]
+	}
+	for i := 0; i < len(h.ranks) && i < len(other.ranks); i++ {
+		if h.ranks[i] != other.ranks[i] {
+			return h.ranks[i] - other.ranks[i]
+		}
+	}
+	return 0
+}
+
+// 5枚のカードの役を判定し、比較可能な値を返す
+func EvaluateHand(cards []*valueobject.Card) (HandValue, error) {
+	if len(cards) != numberOfCards {
+		return HandValue{}, fmt.Errorf("number of cards is not %d", numberOfCards)
+	}
+	// JudgeHandsはカードを並べ替えるため、コピーを渡す
+	p := &Player{cards: append([]*valueobject.Card{}, cards...)}
+	hand, err := p.JudgeHands()
+	if err != nil {
+		return HandValue{}, err
+	}
+	counts := map[int]int{}
+	for _, card := range cards {
+		counts[valueobject.ValueRankMap()[card.Value()]]++
+	}
+	ranks := []int{}
+	for rank := range counts {
+		ranks = append(ranks, rank)
+	}
+	sort.Slice(ranks, func(i, j int) bool {
+		if counts[ranks[i]] != counts[ranks[j]] {
+			return counts[ranks[i]] > counts[ranks[j]]
+		}
+		return ranks[i] > ranks[j]
+	})
+	// A, 2, 3, 4, 5のストレートはAを1として扱う
+	if (hand == "ストレート" || hand == "ストレートフラッシュ") && ranks[0] == 14 && ranks[1] == 5 {
+		ranks = []int{5, 4, 3, 2, 1}
+	}
+	return HandValue{hand: hand, ranks: ranks}, nil
+}
+
+// 5枚以上のカードから作れる最も強い5枚の役を返す
+func EvaluateBestHand(cards []*valueobject.Card) (HandValue, error) {
+	if len(cards) < numberOfCards {
+		return HandValue{}, fmt.Errorf("number of cards is less than %d", numberOfCards)
+	}
+	best := HandValue{}
+	found := false
+	selected := make([]*valueobject.Card, numberOfCards)
+	var choose func(start int, depth int) error
+	choose = func(start int, depth int) error {
+		if depth == numberOfCards {
+			value, err := EvaluateHand(selected)
+			if err != nil {
+				return err
+			}
+			if !found || value.Compare(best) > 0 {
+				best = value
+				found = true
+			}
+			return nil
+		}
+		for i := start; i <= len(cards)-(numberOfCards-depth); i++ {
+			selected[depth] = cards[i]
+			if err := choose(i+1, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := choose(0, 0); err != nil {
+		return HandValue{}, err
+	}
+	return best, nil
+}
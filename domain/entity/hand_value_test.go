@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	valueobject "github.com/KoheiMatsuno99/poker/domain/valueobject"
+)
+
+func mustParseCards(t *testing.T, notation string) []*valueobject.Card {
+	t.Helper()
+	cards, err := valueobject.ParseCards(notation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+func TestEvaluateHand(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     string
+		wantHand  string
+		wantRanks []int
+		wantErr   bool
+	}{
+		{
+			name:      "ワンペア",
+			cards:     "9s4h9d2cKs",
+			wantHand:  "ワンペア",
+			wantRanks: []int{9, 13, 4, 2},
+			wantErr:   false,
+		},
+		{
+			name:      "フルハウス",
+			cards:     "3s3hKd3cKs",
+			wantHand:  "フルハウス",
+			wantRanks: []int{3, 13},
+			wantErr:   false,
+		},
+		{
+			name:      "A,2,3,4,5のストレート",
+			cards:     "As2h3d4c5s",
+			wantHand:  "ストレート",
+			wantRanks: []int{5, 4, 3, 2, 1},
+			wantErr:   false,
+		},
+		{
+			name:      "枚数不足",
+			cards:     "As2h3d4c",
+			wantHand:  "",
+			wantRanks: nil,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateHand(mustParseCards(t, tt.cards))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EvaluateHand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Hand() != tt.wantHand {
+				t.Errorf("EvaluateHand().Hand() = %v, want %v", got.Hand(), tt.wantHand)
+			}
+			if !reflect.DeepEqual(got.Ranks(), tt.wantRanks) {
+				t.Errorf("EvaluateHand().Ranks() = %v, want %v", got.Ranks(), tt.wantRanks)
+			}
+		})
+	}
+}
+
+func TestHandValue_Compare(t *testing.T) {
+	tests := []struct {
+		name  string
+		first string
+		other string
+		want  int
+	}{
+		{
+			name:  "役が強い方が勝つ",
+			first: "2s3s4s5s7s",
+			other: "AsAhAdKcKs",
+			want:  -1,
+		},
+		{
+			name:  "キッカーで勝つ",
+			first: "9s9hAd2c3s",
+			other: "9d9cKd2d3c",
+			want:  1,
+		},
+		{
+			name:  "A,2,3,4,5のストレートは最も弱いストレート",
+			first: "As2h3d4c5s",
+			other: "2s3h4d5c6s",
+			want:  -1,
+		},
+		{
+			name:  "引き分け",
+			first: "9s9hAd2c3s",
+			other: "9d9cAc2d3c",
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := EvaluateHand(mustParseCards(t, tt.first))
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := EvaluateHand(mustParseCards(t, tt.other))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := first.Compare(other)
+			if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+				t.Errorf("HandValue.Compare() = %v, want sign of %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateBestHand(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		wantHand string
+		wantErr  bool
+	}{
+		{
+			name:     "7枚からフラッシュを作る",
+			cards:    "AsKs2s7s9hTsJd",
+			wantHand: "フラッシュ",
+			wantErr:  false,
+		},
+		{
+			name:     "7枚からフルハウスを作る",
+			cards:    "AsAhKdKc2sAd3h",
+			wantHand: "フルハウス",
+			wantErr:  false,
+		},
+		{
+			name:     "枚数不足",
+			cards:    "AsAh",
+			wantHand: "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateBestHand(mustParseCards(t, tt.cards))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EvaluateBestHand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Hand() != tt.wantHand {
+				t.Errorf("EvaluateBestHand().Hand() = %v, want %v", got.Hand(), tt.wantHand)
+			}
+		})
+	}
+}
@@ -8,14 +8,24 @@ import (
 )
 
 type Player struct {
-	name  string
-	money int
-	chips int // 掛け金
-	cards []*valueobject.Card
+	name     string
+	money    valueobject.Money // 所持金
+	stack    int               // テーブル上のチップ
+	chips    int               // 掛け金
+	cards    []*valueobject.Card
 	isActive bool
 }
 
-func NewPlayer(name string, money int) *Player {
+// moneyはDefaultCurrencyの最小単位の額。負の額ならエラーを返す
+func NewPlayer(name string, money int) (*Player, error) {
+	wallet, err := valueobject.NewMoney(int64(money), valueobject.DefaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlayerWithMoney(name, wallet), nil
+}
+
+func NewPlayerWithMoney(name string, money valueobject.Money) *Player {
 	return &Player{
 		name:  name,
 		money: money,
@@ -26,10 +36,14 @@ func (p *Player) Name() string {
 	return p.name
 }
 
-func (p *Player) Money() int {
+func (p *Player) Money() valueobject.Money {
 	return p.money
 }
 
+func (p *Player) Stack() int {
+	return p.stack
+}
+
 func (p *Player) Chips() int {
 	return p.chips
 }
@@ -38,25 +52,176 @@ func (p *Player) Cards() []*valueobject.Card {
 	return p.cards
 }
 
+// テーブルで着席してプレイしている (シットアウトしていない) かどうか
 func (p *Player) IsActive() bool {
 	return p.isActive
 }
 
+func (p *Player) SitIn() {
+	p.isActive = true
+}
+
+func (p *Player) SitOut() {
+	p.isActive = false
+}
+
+// スタックを全て掛けているかどうか
+func (p *Player) IsAllIn() bool {
+	return p.stack == 0 && p.chips > 0
+}
+
 func (p *Player) DrawCard(card *valueobject.Card) {
 	p.cards = append(p.cards, card)
 }
 
+// 手札からcardsを捨てる
+func (p *Player) Discard(cards []*valueobject.Card) error {
+	remaining := append([]*valueobject.Card{}, p.cards...)
+	for _, card := range cards {
+		found := false
+		for i, c := range remaining {
+			if c.Equals(card) {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("card %s is not in hand", card)
+		}
+	}
+	p.cards = remaining
+	return nil
+}
+
+// 次のハンドに備えて手札を返し、返したカードを返す
+func (p *Player) ReturnCards() []*valueobject.Card {
+	cards := p.cards
+	p.cards = nil
+	return cards
+}
+
+// 所持金からamountを支払う
+func (p *Player) Withdraw(amount valueobject.Money) error {
+	money, err := p.money.Subtract(amount)
+	if err != nil {
+		return err
+	}
+	p.money = money
+	return nil
+}
+
+// 所持金にamountを加える
+func (p *Player) Deposit(amount valueobject.Money) error {
+	money, err := p.money.Add(amount)
+	if err != nil {
+		return err
+	}
+	p.money = money
+	return nil
+}
+
+// 所持金からamountをテーブル上のチップに替える。チップ1枚は所持金の最小単位1つ分
+func (p *Player) BuyIn(amount int) error {
+	cost, err := valueobject.NewMoney(int64(amount), p.money.Currency())
+	if err != nil {
+		return err
+	}
+	return p.BuyChips(cost, amount)
+}
+
+// 所持金からcostを支払い、chips枚のチップを受け取る
+func (p *Player) BuyChips(cost valueobject.Money, chips int) error {
+	if chips < 0 {
+		return errors.New("chips must not be negative")
+	}
+	if err := p.Withdraw(cost); err != nil {
+		return err
+	}
+	p.stack += chips
+	return nil
+}
+
+// テーブル上のチップをすべて1枚chipValueで所持金に戻し、戻した額を返す
+func (p *Player) CashOut(chipValue valueobject.Money) (valueobject.Money, error) {
+	if p.chips > 0 {
+		return valueobject.Money{}, errors.New("cannot cash out while chips are in the pot")
+	}
+	proceeds, err := chipValue.Multiply(int64(p.stack))
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	if err := p.Deposit(proceeds); err != nil {
+		return valueobject.Money{}, err
+	}
+	p.stack = 0
+	return proceeds, nil
+}
+
+// 所持金を使わずにスタックにchipsを加える。トーナメントのスターティングスタックなどに使う
+func (p *Player) AddChips(chips int) error {
+	if chips < 0 {
+		return errors.New("chips must not be negative")
+	}
+	p.stack += chips
+	return nil
+}
+
+// 現在のベッティングラウンドの掛け金にchipsを上乗せする
 func (p *Player) Bet(chips int) error {
-	if p.money < chips {
-		return errors.New("not enough money")
+	if chips < 0 {
+		return errors.New("chips must not be negative")
+	}
+	if p.stack < chips {
+		return errors.New("not enough chips")
+	}
+	p.chips += chips
+	p.stack -= chips
+	return nil
+}
+
+// 誰にもコールされなかった掛け金chipsをスタックに戻す
+func (p *Player) ReturnBet(chips int) error {
+	if chips < 0 {
+		return errors.New("chips must not be negative")
 	}
-	p.chips = chips
-	p.money -= chips
+	if p.chips < chips {
+		return errors.New("not enough chips bet")
+	}
+	p.chips -= chips
+	p.stack += chips
 	return nil
 }
 
+// ブラインドを掛け金として支払う。スタックが足りない場合はオールインになり、実際に支払った額を返す
+func (p *Player) PostBlind(amount int) int {
+	posted := p.takeFromStack(amount)
+	p.chips += posted
+	return posted
+}
+
+// アンティを支払う。アンティは掛け金に含めずポットに入るため、支払った額を返す
+func (p *Player) PostAnte(amount int) int {
+	return p.takeFromStack(amount)
+}
+
+func (p *Player) takeFromStack(amount int) int {
+	if amount > p.stack {
+		amount = p.stack
+	}
+	p.stack -= amount
+	return amount
+}
+
+// 掛け金をポットに集めるため、掛け金を0にしてその額を返す
+func (p *Player) CollectChips() int {
+	chips := p.chips
+	p.chips = 0
+	return chips
+}
+
 func (p *Player) Win(chips int) {
-	p.money += chips
+	p.stack += chips
 }
 
 const numberOfCards = 5
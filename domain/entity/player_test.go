@@ -1219,3 +1219,239 @@ func TestPlayer_SeparateFourOfAKindAndOtherCard(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPlayer(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   int
+		wantErr bool
+	}{
+		{
+			name:    "所持金を持ったプレイヤーを作る",
+			money:   100,
+			wantErr: false,
+		},
+		{
+			name:    "所持金が0のプレイヤーを作る",
+			money:   0,
+			wantErr: false,
+		},
+		{
+			name:    "所持金が負の額ならエラーになる",
+			money:   -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPlayer("player", tt.money)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPlayer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && p.Money().Amount() != int64(tt.money) {
+				t.Errorf("Player.Money() = %v, want %v", p.Money(), tt.money)
+			}
+		})
+	}
+}
+
+func TestPlayer_BuyIn(t *testing.T) {
+	tests := []struct {
+		name      string
+		money     int
+		amount    int
+		wantMoney int
+		wantStack int
+		wantErr   bool
+	}{
+		{
+			name:      "所持金の一部をチップに替える",
+			money:     100,
+			amount:    60,
+			wantMoney: 40,
+			wantStack: 60,
+			wantErr:   false,
+		},
+		{
+			name:      "所持金が足りない",
+			money:     100,
+			amount:    160,
+			wantMoney: 100,
+			wantStack: 0,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPlayer("player", tt.money)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.BuyIn(tt.amount); (err != nil) != tt.wantErr {
+				t.Errorf("Player.BuyIn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if p.Money().Amount() != int64(tt.wantMoney) || p.Stack() != tt.wantStack {
+				t.Errorf("Player.BuyIn() money = %v, stack = %v, want %v, %v", p.Money(), p.Stack(), tt.wantMoney, tt.wantStack)
+			}
+		})
+	}
+}
+
+func TestPlayer_PostBlind(t *testing.T) {
+	tests := []struct {
+		name      string
+		stack     int
+		amount    int
+		want      int
+		wantAllIn bool
+		wantChips int
+		wantStack int
+	}{
+		{
+			name:      "ブラインドを支払う",
+			stack:     100,
+			amount:    10,
+			want:      10,
+			wantAllIn: false,
+			wantChips: 10,
+			wantStack: 90,
+		},
+		{
+			name:      "スタックが足りない場合はオールイン",
+			stack:     6,
+			amount:    10,
+			want:      6,
+			wantAllIn: true,
+			wantChips: 6,
+			wantStack: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Player{stack: tt.stack}
+			if got := p.PostBlind(tt.amount); got != tt.want {
+				t.Errorf("Player.PostBlind() = %v, want %v", got, tt.want)
+			}
+			if p.IsAllIn() != tt.wantAllIn {
+				t.Errorf("Player.IsAllIn() = %v, want %v", p.IsAllIn(), tt.wantAllIn)
+			}
+			if p.Chips() != tt.wantChips || p.Stack() != tt.wantStack {
+				t.Errorf("Player.PostBlind() chips = %v, stack = %v, want %v, %v", p.Chips(), p.Stack(), tt.wantChips, tt.wantStack)
+			}
+		})
+	}
+}
+
+func TestPlayer_ReturnBet(t *testing.T) {
+	tests := []struct {
+		name      string
+		chips     int
+		returned  int
+		wantErr   bool
+		wantChips int
+		wantStack int
+	}{
+		{
+			name:      "掛け金の一部を戻す",
+			chips:     30,
+			returned:  20,
+			wantErr:   false,
+			wantChips: 10,
+			wantStack: 70,
+		},
+		{
+			name:      "掛け金より多くは戻せない",
+			chips:     30,
+			returned:  40,
+			wantErr:   true,
+			wantChips: 30,
+			wantStack: 50,
+		},
+		{
+			name:      "負の額",
+			chips:     30,
+			returned:  -1,
+			wantErr:   true,
+			wantChips: 30,
+			wantStack: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Player{chips: tt.chips, stack: 50}
+			if err := p.ReturnBet(tt.returned); (err != nil) != tt.wantErr {
+				t.Errorf("Player.ReturnBet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if p.Chips() != tt.wantChips || p.Stack() != tt.wantStack {
+				t.Errorf("Player.ReturnBet() chips = %v, stack = %v, want %v, %v", p.Chips(), p.Stack(), tt.wantChips, tt.wantStack)
+			}
+		})
+	}
+}
+
+func TestPlayer_AddChips(t *testing.T) {
+	p, err := NewPlayer("alice", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddChips(1500); err != nil {
+		t.Fatal(err)
+	}
+	if p.Stack() != 1500 || p.Money().Amount() != 100 {
+		t.Errorf("Player.AddChips() stack = %v, money = %v, want 1500, 100", p.Stack(), p.Money())
+	}
+	if err := p.AddChips(-1); err == nil {
+		t.Errorf("Player.AddChips() error = nil, want error")
+	}
+}
+
+func mustMoney(t *testing.T, amount int64, currency valueobject.Currency) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return money
+}
+
+func TestPlayer_WithdrawAndDeposit(t *testing.T) {
+	p := NewPlayerWithMoney("alice", mustMoney(t, 1000, valueobject.USD))
+	if err := p.Withdraw(mustMoney(t, 1001, valueobject.USD)); err == nil {
+		t.Errorf("Player.Withdraw() over the balance error = nil, want error")
+	}
+	if err := p.Withdraw(mustMoney(t, 100, valueobject.JPY)); err == nil {
+		t.Errorf("Player.Withdraw() in another currency error = nil, want error")
+	}
+	if err := p.Withdraw(mustMoney(t, 400, valueobject.USD)); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Deposit(mustMoney(t, 150, valueobject.USD)); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Money().String(); got != "$7.50" {
+		t.Errorf("Player.Money() = %v, want $7.50", got)
+	}
+}
+
+func TestPlayer_CashOut(t *testing.T) {
+	// チップ1枚が10セントのテーブルで、30ドル分のチップを買う
+	chipValue := mustMoney(t, 10, valueobject.USD)
+	p := NewPlayerWithMoney("alice", mustMoney(t, 10000, valueobject.USD))
+	if err := p.BuyChips(mustMoney(t, 3000, valueobject.USD), 300); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Bet(50); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.CashOut(chipValue); err == nil {
+		t.Errorf("Player.CashOut() with chips in the pot error = nil, want error")
+	}
+	p.CollectChips()
+	proceeds, err := p.CashOut(chipValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proceeds.Amount() != 2500 || p.Stack() != 0 || p.Money().Amount() != 9500 {
+		t.Errorf("Player.CashOut() = %v, stack = %v, money = %v, want $25.00, 0, $95.00", proceeds, p.Stack(), p.Money())
+	}
+}
@@ -0,0 +1,328 @@
+package valueobject
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 2枚のホールカードの組み合わせ
+type Combo struct {
+	cards [2]*Card
+}
+
+func NewCombo(first *Card, second *Card) Combo {
+	// 同じ組み合わせが同じ値になるよう、強いカードを先にする
+	if cardOrder(second) > cardOrder(first) {
+		first, second = second, first
+	}
+	return Combo{cards: [2]*Card{first, second}}
+}
+
+func (c Combo) Cards() []*Card {
+	return []*Card{c.cards[0], c.cards[1]}
+}
+
+func (c Combo) String() string {
+	return c.cards[0].String() + c.cards[1].String()
+}
+
+func (c Combo) Equals(other Combo) bool {
+	return c.cards[0].Equals(other.cards[0]) && c.cards[1].Equals(other.cards[1])
+}
+
+// コンボのカードがdeadのいずれかと重なっているかどうかを判定する
+func (c Combo) IsBlockedBy(dead []*Card) bool {
+	return ContainsCard(dead, c.cards[0]) || ContainsCard(dead, c.cards[1])
+}
+
+// 2つのコンボが同じカードを共有しているかどうかを判定する
+func (c Combo) Overlaps(other Combo) bool {
+	return other.IsBlockedBy(c.Cards())
+}
+
+func cardOrder(card *Card) int {
+	return valueRankMap[card.value]*10 + suitRankMap[card.suit]
+}
+
+// 重み付きのコンボ。重みは0より大きく1以下
+type WeightedCombo struct {
+	Combo  Combo
+	Weight float64
+}
+
+// "TT+, AKs, KQo, A5s-A2s" のような表記で表されるハンドレンジ
+type HandRange struct {
+	combos []WeightedCombo
+}
+
+func NewHandRange(combos []WeightedCombo) *HandRange {
+	r := &HandRange{}
+	for _, combo := range combos {
+		r.add(combo.Combo, combo.Weight)
+	}
+	return r
+}
+
+func (r *HandRange) Combos() []WeightedCombo {
+	return r.combos
+}
+
+// 重みを考慮したコンボ数を返す
+func (r *HandRange) Size() float64 {
+	size := 0.0
+	for _, combo := range r.combos {
+		size += combo.Weight
+	}
+	return size
+}
+
+// コンボの重みを返す。レンジに含まれない場合は0
+func (r *HandRange) Weight(combo Combo) float64 {
+	for _, c := range r.combos {
+		if c.Combo.Equals(combo) {
+			return c.Weight
+		}
+	}
+	return 0
+}
+
+// 既に含まれているコンボは重みの大きい方を採用する
+func (r *HandRange) add(combo Combo, weight float64) {
+	for i, c := range r.combos {
+		if c.Combo.Equals(combo) {
+			if weight > c.Weight {
+				r.combos[i].Weight = weight
+			}
+			return
+		}
+	}
+	r.combos = append(r.combos, WeightedCombo{Combo: combo, Weight: weight})
+}
+
+// 既知のカードと重なるコンボを取り除いたレンジを返す
+func (r *HandRange) RemoveBlocked(dead []*Card) *HandRange {
+	result := &HandRange{}
+	for _, c := range r.combos {
+		if !c.Combo.IsBlockedBy(dead) {
+			result.combos = append(result.combos, c)
+		}
+	}
+	return result
+}
+
+// 和集合。両方に含まれるコンボは重みの大きい方を採用する
+func (r *HandRange) Union(other *HandRange) *HandRange {
+	result := NewHandRange(r.combos)
+	for _, c := range other.combos {
+		result.add(c.Combo, c.Weight)
+	}
+	return result
+}
+
+// 積集合。両方に含まれるコンボは重みの小さい方を採用する
+func (r *HandRange) Intersect(other *HandRange) *HandRange {
+	result := &HandRange{}
+	for _, c := range r.combos {
+		weight := other.Weight(c.Combo)
+		if weight == 0 {
+			continue
+		}
+		if c.Weight < weight {
+			weight = c.Weight
+		}
+		result.combos = append(result.combos, WeightedCombo{Combo: c.Combo, Weight: weight})
+	}
+	return result
+}
+
+// 差集合。otherに含まれるコンボを取り除く
+func (r *HandRange) Difference(other *HandRange) *HandRange {
+	result := &HandRange{}
+	for _, c := range r.combos {
+		if other.Weight(c.Combo) == 0 {
+			result.combos = append(result.combos, c)
+		}
+	}
+	return result
+}
+
+var rankOrder = []string{"2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K", "A"}
+
+func rankIndex(rank string) int {
+	for i, r := range rankOrder {
+		if r == rank {
+			return i
+		}
+	}
+	return -1
+}
+
+// レンジ表記を解析する
+// 対応する表記: AA, TT+, TT-77, AKs, AKo, AK, ATs+, A5s-A2s, AsKd, および末尾の重み指定 (AKs:0.5)
+func ParseHandRange(notation string) (*HandRange, error) {
+	r := &HandRange{}
+	for _, token := range strings.Split(notation, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		weight := 1.0
+		if i := strings.Index(token, ":"); i >= 0 {
+			w, err := strconv.ParseFloat(token[i+1:], 64)
+			if err != nil || w <= 0 || w > 1 {
+				return nil, fmt.Errorf("invalid weight: %s", token)
+			}
+			weight = w
+			token = token[:i]
+		}
+		combos, err := parseRangeToken(token)
+		if err != nil {
+			return nil, err
+		}
+		for _, combo := range combos {
+			r.add(combo, weight)
+		}
+	}
+	return r, nil
+}
+
+func parseRangeToken(token string) ([]Combo, error) {
+	// AsKdのような具体的なコンボ
+	if len(token) == 4 {
+		if _, ok := suitNotationMap[token[1:2]]; ok {
+			cards, err := ParseCards(token)
+			if err != nil {
+				return nil, err
+			}
+			if cards[0].Equals(cards[1]) {
+				return nil, fmt.Errorf("invalid combo: %s", token)
+			}
+			return []Combo{NewCombo(cards[0], cards[1])}, nil
+		}
+	}
+	if i := strings.Index(token, "-"); i >= 0 {
+		return parseRangeSpan(token[:i], token[i+1:])
+	}
+	plus := strings.HasSuffix(token, "+")
+	hand, err := parseHandClass(strings.TrimSuffix(token, "+"))
+	if err != nil {
+		return nil, err
+	}
+	if !plus {
+		return hand.combos(), nil
+	}
+	combos := []Combo{}
+	if hand.isPair() {
+		// TT+ はTTからAAまで
+		for i := hand.high; i < len(rankOrder); i++ {
+			combos = append(combos, handClass{high: i, low: i}.combos()...)
+		}
+		return combos, nil
+	}
+	// ATs+ はATsからAKsまで
+	for i := hand.low; i < hand.high; i++ {
+		combos = append(combos, handClass{high: hand.high, low: i, suited: hand.suited, offsuit: hand.offsuit}.combos()...)
+	}
+	return combos, nil
+}
+
+// A5s-A2s や TT-77 のような範囲指定を解析する
+func parseRangeSpan(from string, to string) ([]Combo, error) {
+	start, err := parseHandClass(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseHandClass(to)
+	if err != nil {
+		return nil, err
+	}
+	if start.isPair() != end.isPair() || start.suited != end.suited || start.offsuit != end.offsuit {
+		return nil, fmt.Errorf("invalid range: %s-%s", from, to)
+	}
+	combos := []Combo{}
+	if start.isPair() {
+		low, high := end.high, start.high
+		if low > high {
+			low, high = high, low
+		}
+		for i := low; i <= high; i++ {
+			combos = append(combos, handClass{high: i, low: i}.combos()...)
+		}
+		return combos, nil
+	}
+	if start.high != end.high {
+		return nil, fmt.Errorf("invalid range: %s-%s", from, to)
+	}
+	low, high := end.low, start.low
+	if low > high {
+		low, high = high, low
+	}
+	for i := low; i <= high; i++ {
+		combos = append(combos, handClass{high: start.high, low: i, suited: start.suited, offsuit: start.offsuit}.combos()...)
+	}
+	return combos, nil
+}
+
+// AKsのようなスートを特定しないハンドの種類
+type handClass struct {
+	high    int
+	low     int
+	suited  bool
+	offsuit bool
+}
+
+func parseHandClass(notation string) (handClass, error) {
+	if len(notation) != 2 && len(notation) != 3 {
+		return handClass{}, fmt.Errorf("invalid hand: %s", notation)
+	}
+	high := rankIndex(notation[0:1])
+	low := rankIndex(notation[1:2])
+	if high < 0 || low < 0 {
+		return handClass{}, fmt.Errorf("invalid hand: %s", notation)
+	}
+	if low > high {
+		high, low = low, high
+	}
+	hand := handClass{high: high, low: low}
+	if len(notation) == 3 {
+		switch notation[2:3] {
+		case "s":
+			hand.suited = true
+		case "o":
+			hand.offsuit = true
+		default:
+			return handClass{}, fmt.Errorf("invalid hand: %s", notation)
+		}
+		if hand.isPair() {
+			return handClass{}, fmt.Errorf("pair cannot be suited or offsuit: %s", notation)
+		}
+	}
+	return hand, nil
+}
+
+func (h handClass) isPair() bool {
+	return h.high == h.low
+}
+
+func (h handClass) combos() []Combo {
+	suits := []string{"spade", "heart", "diamond", "club"}
+	highValue := rankNotationMap[rankOrder[h.high]]
+	lowValue := rankNotationMap[rankOrder[h.low]]
+	combos := []Combo{}
+	for i, firstSuit := range suits {
+		for j, secondSuit := range suits {
+			if h.isPair() && j <= i {
+				continue
+			}
+			if h.suited && firstSuit != secondSuit {
+				continue
+			}
+			if h.offsuit && firstSuit == secondSuit {
+				continue
+			}
+			combos = append(combos, NewCombo(NewCard(firstSuit, highValue), NewCard(secondSuit, lowValue)))
+		}
+	}
+	return combos
+}
@@ -0,0 +1,213 @@
+package valueobject
+
+import (
+	"testing"
+)
+
+func TestParseHandRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		want     float64
+		wantErr  bool
+	}{
+		{
+			name:     "ペア",
+			notation: "AA",
+			want:     6,
+			wantErr:  false,
+		},
+		{
+			name:     "ペア以上",
+			notation: "TT+",
+			want:     30,
+			wantErr:  false,
+		},
+		{
+			name:     "ペアの範囲指定",
+			notation: "TT-77",
+			want:     24,
+			wantErr:  false,
+		},
+		{
+			name:     "スーテッド",
+			notation: "AKs",
+			want:     4,
+			wantErr:  false,
+		},
+		{
+			name:     "オフスート",
+			notation: "KQo",
+			want:     12,
+			wantErr:  false,
+		},
+		{
+			name:     "スート指定なし",
+			notation: "AK",
+			want:     16,
+			wantErr:  false,
+		},
+		{
+			name:     "キッカー以上",
+			notation: "ATs+",
+			want:     16,
+			wantErr:  false,
+		},
+		{
+			name:     "キッカーの範囲指定",
+			notation: "A5s-A2s",
+			want:     16,
+			wantErr:  false,
+		},
+		{
+			name:     "具体的なコンボ",
+			notation: "AsKd",
+			want:     1,
+			wantErr:  false,
+		},
+		{
+			name:     "重み付き",
+			notation: "AKs:0.5, QQ",
+			want:     8,
+			wantErr:  false,
+		},
+		{
+			name:     "複数の表記",
+			notation: "TT+, AKs, KQo, A5s-A2s, 65s",
+			want:     66,
+			wantErr:  false,
+		},
+		{
+			name:     "重複は一度だけ数える",
+			notation: "AK, AKs",
+			want:     16,
+			wantErr:  false,
+		},
+		{
+			name:     "不正な表記",
+			notation: "AXs",
+			want:     0,
+			wantErr:  true,
+		},
+		{
+			name:     "ペアにスート指定",
+			notation: "AAs",
+			want:     0,
+			wantErr:  true,
+		},
+		{
+			name:     "不正な重み",
+			notation: "AKs:2",
+			want:     0,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHandRange(tt.notation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseHandRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Size() != tt.want {
+				t.Errorf("ParseHandRange().Size() = %v, want %v", got.Size(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHandRange_RemoveBlocked(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		dead     []*Card
+		want     float64
+	}{
+		{
+			name:     "1枚ブロックされたペア",
+			notation: "AA",
+			dead:     []*Card{NewCard("spade", "A")},
+			want:     3,
+		},
+		{
+			name:     "2枚ブロックされたペア",
+			notation: "AA",
+			dead:     []*Card{NewCard("spade", "A"), NewCard("heart", "A")},
+			want:     1,
+		},
+		{
+			name:     "関係ないカード",
+			notation: "AKs",
+			dead:     []*Card{NewCard("spade", "2")},
+			want:     4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseHandRange(tt.notation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.RemoveBlocked(tt.dead).Size(); got != tt.want {
+				t.Errorf("HandRange.RemoveBlocked().Size() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandRange_SetOperations(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     string
+		second    string
+		operation func(a, b *HandRange) *HandRange
+		want      float64
+	}{
+		{
+			name:      "和集合",
+			first:     "QQ+",
+			second:    "JJ-TT, AA",
+			operation: (*HandRange).Union,
+			want:      30,
+		},
+		{
+			name:      "積集合",
+			first:     "TT+",
+			second:    "KK-77",
+			operation: (*HandRange).Intersect,
+			want:      24,
+		},
+		{
+			name:      "積集合は重みの小さい方を採用する",
+			first:     "AA:0.5",
+			second:    "AA",
+			operation: (*HandRange).Intersect,
+			want:      3,
+		},
+		{
+			name:      "差集合",
+			first:     "TT+",
+			second:    "AA",
+			operation: (*HandRange).Difference,
+			want:      24,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseHandRange(tt.first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseHandRange(tt.second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.operation(a, b).Size(); got != tt.want {
+				t.Errorf("Size() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
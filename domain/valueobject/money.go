@@ -0,0 +1,236 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 通貨。MinorUnitsは最小単位の桁数で、円なら0、ドルなら2 (1セント = 0.01ドル)
+type Currency struct {
+	Code       string
+	Symbol     string
+	MinorUnits int
+}
+
+var (
+	JPY = Currency{Code: "JPY", Symbol: "¥", MinorUnits: 0}
+	USD = Currency{Code: "USD", Symbol: "$", MinorUnits: 2}
+	EUR = Currency{Code: "EUR", Symbol: "€", MinorUnits: 2}
+)
+
+// 通貨を指定しない金額に使う通貨
+var DefaultCurrency = JPY
+
+var currencies = map[string]Currency{
+	JPY.Code: JPY,
+	USD.Code: USD,
+	EUR.Code: EUR,
+}
+
+func CurrencyByCode(code string) (Currency, error) {
+	currency, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("unknown currency: %s", code)
+	}
+	return currency, nil
+}
+
+// 金額。最小単位の整数で持ち、負の金額は扱わない
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// 最小単位の額amountから金額を作る。1ドルならNewMoney(100, USD)
+func NewMoney(amount int64, currency Currency) (Money, error) {
+	if amount < 0 {
+		return Money{}, errors.New("money must not be negative")
+	}
+	return Money{amount: amount, currency: currency}, nil
+}
+
+func ZeroMoney(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// 通貨の最小単位1つ分の金額。1円や1セント
+func MinorUnit(currency Currency) Money {
+	return Money{amount: 1, currency: currency}
+}
+
+// 最小単位の額
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) Equals(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+// 同じ通貨の金額を比べ、mのほうが少なければ負、多ければ正の値を返す
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.requireSameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.requireSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if m.amount > math.MaxInt64-other.amount {
+		return Money{}, errors.New("money overflow")
+	}
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+// otherを差し引く。結果が負になる場合はエラーを返す
+func (m Money) Subtract(other Money) (Money, error) {
+	if err := m.requireSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if m.amount < other.amount {
+		return Money{}, errors.New("not enough money")
+	}
+	return Money{amount: m.amount - other.amount, currency: m.currency}, nil
+}
+
+func (m Money) Multiply(n int64) (Money, error) {
+	if n < 0 {
+		return Money{}, errors.New("multiplier must not be negative")
+	}
+	if n > 0 && m.amount > math.MaxInt64/n {
+		return Money{}, errors.New("money overflow")
+	}
+	return Money{amount: m.amount * n, currency: m.currency}, nil
+}
+
+// 金額をn個に分ける。割り切れない端数は先頭から最小単位ずつ配り、合計が元の金額と一致する
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, errors.New("number of parts must be positive")
+	}
+	parts := make([]Money, n)
+	share, remainder := m.amount/int64(n), m.amount%int64(n)
+	for i := range parts {
+		parts[i] = Money{amount: share, currency: m.currency}
+		if int64(i) < remainder {
+			parts[i].amount++
+		}
+	}
+	return parts, nil
+}
+
+// 金額を比率ratiosで分ける。各取り分は切り捨て、端数は先頭から最小単位ずつ配るので合計は元の金額と一致する
+func (m Money) Allocate(ratios []int) ([]Money, error) {
+	total := int64(0)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, errors.New("ratio must not be negative")
+		}
+		total += int64(ratio)
+	}
+	if total == 0 {
+		return nil, errors.New("ratios must not add up to zero")
+	}
+	parts := make([]Money, len(ratios))
+	quotient, remainder := m.amount/total, m.amount%total
+	allocated := int64(0)
+	for i, ratio := range ratios {
+		// amount*ratio/totalを桁あふれしないように計算する
+		share := quotient*int64(ratio) + remainder*int64(ratio)/total
+		parts[i] = Money{amount: share, currency: m.currency}
+		allocated += share
+	}
+	for i := 0; allocated < m.amount; i = (i + 1) % len(parts) {
+		if ratios[i] > 0 {
+			parts[i].amount++
+			allocated++
+		}
+	}
+	return parts, nil
+}
+
+func (m Money) requireSameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("currency mismatch: %s and %s", m.currency.Code, other.currency.Code)
+	}
+	return nil
+}
+
+// "$1,234.50"や"¥1,200"のような表記
+func (m Money) String() string {
+	digits := strconv.FormatInt(m.amount, 10)
+	units := m.currency.MinorUnits
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+	major, minor := digits[:len(digits)-units], digits[len(digits)-units:]
+	var sb strings.Builder
+	sb.WriteString(m.currency.Symbol)
+	if m.currency.Symbol == "" {
+		sb.WriteString(m.currency.Code + " ")
+	}
+	for i, digit := range major {
+		if i > 0 && (len(major)-i)%3 == 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteRune(digit)
+	}
+	if units > 0 {
+		sb.WriteString("." + minor)
+	}
+	return sb.String()
+}
+
+// 額面ごとのチップの枚数
+type ChipCount struct {
+	Denomination int
+	Count        int
+}
+
+// chipsを額面の大きいチップから順に組み合わせて表す。denominationsには1を含めるか、chipsを表せる額面を渡す
+func ChipDenominations(chips int, denominations []int) ([]ChipCount, error) {
+	if chips < 0 {
+		return nil, errors.New("chips must not be negative")
+	}
+	sorted := append([]int{}, denominations...)
+	for _, denomination := range sorted {
+		if denomination <= 0 {
+			return nil, errors.New("denomination must be positive")
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	counts := []ChipCount{}
+	remaining := chips
+	for _, denomination := range sorted {
+		if count := remaining / denomination; count > 0 {
+			counts = append(counts, ChipCount{Denomination: denomination, Count: count})
+			remaining -= count * denomination
+		}
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("%d chips cannot be made with the denominations", chips)
+	}
+	return counts, nil
+}
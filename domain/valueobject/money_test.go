@@ -0,0 +1,179 @@
+package valueobject
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func mustMoney(t *testing.T, amount int64, currency Currency) Money {
+	t.Helper()
+	money, err := NewMoney(amount, currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return money
+}
+
+func TestNewMoney(t *testing.T) {
+	if _, err := NewMoney(-1, USD); err == nil {
+		t.Errorf("NewMoney() with a negative amount error = nil, want error")
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{
+			name:  "円",
+			money: Money{amount: 1200, currency: JPY},
+			want:  "¥1,200",
+		},
+		{
+			name:  "ドル",
+			money: Money{amount: 123450, currency: USD},
+			want:  "$1,234.50",
+		},
+		{
+			name:  "1ドル未満",
+			money: Money{amount: 5, currency: EUR},
+			want:  "€0.05",
+		},
+		{
+			name:  "0円",
+			money: Money{currency: JPY},
+			want:  "¥0",
+		},
+		{
+			name:  "記号のない通貨",
+			money: Money{amount: 1234567, currency: Currency{Code: "KRW"}},
+			want:  "KRW 1,234,567",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.want {
+				t.Errorf("Money.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	ten, three := mustMoney(t, 1000, USD), mustMoney(t, 300, USD)
+	sum, err := ten.Add(three)
+	if err != nil || sum.Amount() != 1300 {
+		t.Errorf("Money.Add() = %v, %v, want $13.00", sum, err)
+	}
+	difference, err := ten.Subtract(three)
+	if err != nil || difference.Amount() != 700 {
+		t.Errorf("Money.Subtract() = %v, %v, want $7.00", difference, err)
+	}
+	if _, err := three.Subtract(ten); err == nil {
+		t.Errorf("Money.Subtract() below zero error = nil, want error")
+	}
+	if _, err := ten.Add(mustMoney(t, 1000, JPY)); err == nil {
+		t.Errorf("Money.Add() with another currency error = nil, want error")
+	}
+	if _, err := mustMoney(t, math.MaxInt64, USD).Add(MinorUnit(USD)); err == nil {
+		t.Errorf("Money.Add() overflow error = nil, want error")
+	}
+	if _, err := mustMoney(t, math.MaxInt64/2+1, USD).Multiply(2); err == nil {
+		t.Errorf("Money.Multiply() overflow error = nil, want error")
+	}
+	if c, err := three.Compare(ten); err != nil || c >= 0 {
+		t.Errorf("Money.Compare() = %v, %v, want negative", c, err)
+	}
+}
+
+func TestMoney_Split(t *testing.T) {
+	parts, err := mustMoney(t, 100, USD).Split(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []int64{}
+	for _, part := range parts {
+		got = append(got, part.Amount())
+	}
+	if want := []int64{34, 33, 33}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Money.Split() = %v, want %v", got, want)
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		ratios []int
+		want   []int64
+	}{
+		{
+			name:   "割り切れる",
+			amount: 1000,
+			ratios: []int{5000, 3000, 2000},
+			want:   []int64{500, 300, 200},
+		},
+		{
+			name:   "端数は先頭から配る",
+			amount: 32,
+			ratios: []int{5000, 3000, 2000},
+			want:   []int64{17, 9, 6},
+		},
+		{
+			name:   "比率0には配らない",
+			amount: 5,
+			ratios: []int{0, 1, 1},
+			want:   []int64{0, 3, 2},
+		},
+		{
+			name:   "大きな額でも桁あふれしない",
+			amount: math.MaxInt64,
+			ratios: []int{1, 1},
+			want:   []int64{math.MaxInt64/2 + 1, math.MaxInt64 / 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := mustMoney(t, tt.amount, JPY).Allocate(tt.ratios)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int64{}
+			for _, part := range parts {
+				got = append(got, part.Amount())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Money.Allocate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := mustMoney(t, 10, JPY).Allocate([]int{0, 0}); err == nil {
+		t.Errorf("Money.Allocate() with zero ratios error = nil, want error")
+	}
+}
+
+func TestChipDenominations(t *testing.T) {
+	got, err := ChipDenominations(1835, []int{5, 100, 25, 1000, 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ChipCount{{Denomination: 1000, Count: 1}, {Denomination: 500, Count: 1}, {Denomination: 100, Count: 3}, {Denomination: 25, Count: 1}, {Denomination: 5, Count: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChipDenominations() = %v, want %v", got, want)
+	}
+	if _, err := ChipDenominations(7, []int{5, 25}); err == nil {
+		t.Errorf("ChipDenominations() that cannot be made error = nil, want error")
+	}
+}
+
+func TestCurrencyByCode(t *testing.T) {
+	if currency, err := CurrencyByCode("usd"); err != nil || currency != USD {
+		t.Errorf("CurrencyByCode() = %v, %v, want USD", currency, err)
+	}
+	if _, err := CurrencyByCode("XXX"); err == nil {
+		t.Errorf("CurrencyByCode() with an unknown code error = nil, want error")
+	}
+}
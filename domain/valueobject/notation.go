@@ -0,0 +1,106 @@
+package valueobject
+
+import "fmt"
+
+var rankNotationMap = map[string]string{
+	"A": "A",
+	"K": "K",
+	"Q": "Q",
+	"J": "J",
+	"T": "10",
+	"9": "9",
+	"8": "8",
+	"7": "7",
+	"6": "6",
+	"5": "5",
+	"4": "4",
+	"3": "3",
+	"2": "2",
+}
+
+var suitNotationMap = map[string]string{
+	"s": "spade",
+	"h": "heart",
+	"d": "diamond",
+	"c": "club",
+}
+
+// "As", "Td", "10h" のような表記からカードを生成する
+func ParseCard(notation string) (*Card, error) {
+	if len(notation) < 2 {
+		return nil, fmt.Errorf("invalid card notation: %s", notation)
+	}
+	rank := notation[:len(notation)-1]
+	if rank == "10" {
+		rank = "T"
+	}
+	value, ok := rankNotationMap[rank]
+	if !ok {
+		return nil, fmt.Errorf("invalid rank: %s", notation)
+	}
+	suit, ok := suitNotationMap[notation[len(notation)-1:]]
+	if !ok {
+		return nil, fmt.Errorf("invalid suit: %s", notation)
+	}
+	return NewCard(suit, value), nil
+}
+
+// "AsKd" や "10hJh" のように連続した表記から複数のカードを生成する
+func ParseCards(notation string) ([]*Card, error) {
+	cards := []*Card{}
+	for i := 0; i < len(notation); {
+		// 10は数字で表記すると3文字になる
+		size := 2
+		if len(notation) >= i+2 && notation[i:i+2] == "10" {
+			size = 3
+		}
+		if i+size > len(notation) {
+			return nil, fmt.Errorf("invalid cards notation: %s", notation)
+		}
+		card, err := ParseCard(notation[i : i+size])
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+		i += size
+	}
+	return cards, nil
+}
+
+// ランクの表記を返す (10はTとして表す)
+func RankNotation(value string) string {
+	if value == "10" {
+		return "T"
+	}
+	return value
+}
+
+func (c *Card) String() string {
+	return RankNotation(c.value) + c.suit[:1]
+}
+
+// スートとランクが同じカードかどうかを判定する
+func (c *Card) Equals(other *Card) bool {
+	return c.suit == other.suit && c.value == other.value
+}
+
+// 52枚のカードを返す
+func FullDeck() []*Card {
+	deck := []*Card{}
+	for _, suit := range Suits() {
+		for _, value := range Values() {
+			deck = append(deck, NewCard(suit, value))
+		}
+	}
+	return deck
+}
+
+// cardsの中にcardと同じカードが含まれているかどうかを判定する
+func ContainsCard(cards []*Card, card *Card) bool {
+	for _, c := range cards {
+		if c.Equals(card) {
+			return true
+		}
+	}
+	return false
+}
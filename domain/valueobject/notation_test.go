@@ -0,0 +1,136 @@
+package valueobject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		want     *Card
+		wantErr  bool
+	}{
+		{
+			name:     "エース",
+			notation: "As",
+			want:     NewCard("spade", "A"),
+			wantErr:  false,
+		},
+		{
+			name:     "10をTで表記",
+			notation: "Td",
+			want:     NewCard("diamond", "10"),
+			wantErr:  false,
+		},
+		{
+			name:     "10を数字で表記",
+			notation: "10h",
+			want:     NewCard("heart", "10"),
+			wantErr:  false,
+		},
+		{
+			name:     "不正なスート",
+			notation: "Ax",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "不正なランク",
+			notation: "1c",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCard(tt.notation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCard() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		want     []*Card
+		wantErr  bool
+	}{
+		{
+			name:     "2枚",
+			notation: "AsKd",
+			want:     []*Card{NewCard("spade", "A"), NewCard("diamond", "K")},
+			wantErr:  false,
+		},
+		{
+			name:     "10を数字で表記",
+			notation: "10hJhTc",
+			want:     []*Card{NewCard("heart", "10"), NewCard("heart", "J"), NewCard("club", "10")},
+			wantErr:  false,
+		},
+		{
+			name:     "空の表記",
+			notation: "",
+			want:     []*Card{},
+			wantErr:  false,
+		},
+		{
+			name:     "スートが足りない",
+			notation: "AsK",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "10のスートが足りない",
+			notation: "As10",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCards(tt.notation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCards() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCard_String(t *testing.T) {
+	tests := []struct {
+		name string
+		card *Card
+		want string
+	}{
+		{
+			name: "エース",
+			card: NewCard("spade", "A"),
+			want: "As",
+		},
+		{
+			name: "10",
+			card: NewCard("club", "10"),
+			want: "Tc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.card.String(); got != tt.want {
+				t.Errorf("Card.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}